@@ -0,0 +1,42 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/maverickkamal/Packet-Rush/internal/editor"
+)
+
+func runEdit(args []string) int {
+	flags := flag.NewFlagSet("edit", flag.ExitOnError)
+	level := flags.Int("level", 1, "level number for a new level")
+	width := flags.Int("width", editor.DefaultWidth, "grid width for a new level")
+	height := flags.Int("height", editor.DefaultHeight, "grid height for a new level")
+	flags.Usage = func() {
+		fmt.Println("Usage: packet-rush edit [-level n] [-width w] [-height h] [file]")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	path := "level.json"
+	if flags.NArg() > 0 {
+		path = flags.Arg(0)
+	}
+	if *width < 3 || *height < 3 {
+		fmt.Println("A level needs to be at least 3x3")
+		return 1
+	}
+
+	e, err := editor.Open(path, *level, *width, *height)
+	if err != nil {
+		fmt.Printf("Error opening level: %v\n", err)
+		return 1
+	}
+
+	if _, err := tea.NewProgram(e, tea.WithMouseCellMotion()).Run(); err != nil {
+		fmt.Printf("Error running editor: %v\n", err)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/maverickkamal/Packet-Rush/internal/levels"
+)
+
+func runGenerate(args []string) int {
+	flags := flag.NewFlagSet("generate", flag.ExitOnError)
+	level := flags.Int("level", 11, "level number; the difficulty defaults to that level of the endless progression")
+	seed := flags.Int64("seed", time.Now().UnixNano(), "random seed for the layout")
+	defaults := levels.DifficultyForLevel(*level)
+	width := flags.Int("width", 0, fmt.Sprintf("grid width (default %d)", defaults.Width))
+	height := flags.Int("height", 0, fmt.Sprintf("grid height (default %d)", defaults.Height))
+	junctions := flags.Int("junctions", -1, "switchable junctions to aim for")
+	branching := flags.Int("branching", 0, "most routes out of one junction, 2 or 3")
+	pathLength := flags.Int("path", -1, "fewest track segments from the spawn to any letter")
+	destinations := flags.Int("letters", 0, "different letters in the target word")
+	decoys := flags.Int("decoys", -1, "extra letters that aren't in the word")
+	interval := flags.Duration("spawn", 0, "spawn interval")
+	flags.Usage = func() {
+		fmt.Println("Usage: packet-rush generate [flags] <file>")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return 2
+	}
+
+	// The level may have been set on the command line, so take the
+	// defaults again before applying the overrides.
+	d := levels.DifficultyForLevel(*level)
+	for _, override := range []struct {
+		value *int
+		unset int
+		field *int
+	}{
+		{width, 0, &d.Width},
+		{height, 0, &d.Height},
+		{junctions, -1, &d.Junctions},
+		{branching, 0, &d.Branching},
+		{pathLength, -1, &d.PathLength},
+		{destinations, 0, &d.Destinations},
+		{decoys, -1, &d.Decoys},
+	} {
+		if *override.value != override.unset {
+			*override.field = *override.value
+		}
+	}
+	if *interval > 0 {
+		d.SpawnInterval = *interval
+	}
+
+	data, err := levels.Generate(*seed, d, *level)
+	if err != nil {
+		fmt.Printf("Error generating level: %v\n", err)
+		return 1
+	}
+
+	file := levels.LevelFile{
+		Level:         *level,
+		Grid:          data.Grid,
+		SpawnInterval: data.SpawnInterval.String(),
+		Goal:          data.Goal,
+		TargetWord:    data.TargetWord,
+	}
+	path := flags.Arg(0)
+	if err := file.Save(path); err != nil {
+		fmt.Printf("Error saving level: %v\n", err)
+		return 1
+	}
+	fmt.Printf("Level %d (seed %d, spelling %s) saved to %s\n", *level, *seed, data.TargetWord, path)
+	return 0
+}
@@ -0,0 +1,77 @@
+package bot
+
+import (
+	"github.com/maverickkamal/Packet-Rush/internal/levels"
+	"github.com/maverickkamal/Packet-Rush/internal/types"
+)
+
+// DefaultMaxTicks is long enough for any level the bot can win at all.
+const DefaultMaxTicks = 5000
+
+// Result is how one headless game went.
+type Result struct {
+	Seed  int64
+	Won   bool
+	Ticks int
+	Lives int
+	Score int
+}
+
+// Play lets the autopilot run sim until the level ends or maxTicks pass.
+func Play(sim *types.Simulation, maxTicks int) Result {
+	for !sim.Finished() && sim.GameTime < maxTicks {
+		sim.Step(sim.AutopilotInputs())
+	}
+	return Result{
+		Seed:  sim.Seed,
+		Won:   sim.LevelComplete,
+		Ticks: sim.GameTime,
+		Lives: sim.Lives,
+		Score: sim.Score,
+	}
+}
+
+// Report sums up a level played once per seed.
+type Report struct {
+	Level   int
+	Results []Result
+}
+
+func CheckLevel(level int, seeds []int64, maxTicks int) Report {
+	report := Report{Level: level}
+	for _, seed := range seeds {
+		report.Results = append(report.Results, Play(levels.NewSimulationForLevel(level, seed), maxTicks))
+	}
+	return report
+}
+
+func (r Report) Wins() int {
+	wins := 0
+	for _, result := range r.Results {
+		if result.Won {
+			wins++
+		}
+	}
+	return wins
+}
+
+// Beatable reports whether the bot won with at least one seed, which proves
+// the level can be beaten at its spawn interval and tick speed.
+func (r Report) Beatable() bool {
+	return r.Wins() > 0
+}
+
+// AverageWin is the mean ticks and lives left over the games that were won.
+func (r Report) AverageWin() (ticks, lives float64) {
+	wins := r.Wins()
+	if wins == 0 {
+		return 0, 0
+	}
+	for _, result := range r.Results {
+		if result.Won {
+			ticks += float64(result.Ticks)
+			lives += float64(result.Lives)
+		}
+	}
+	return ticks / float64(wins), lives / float64(wins)
+}
@@ -0,0 +1,29 @@
+package bot
+
+import (
+	"testing"
+
+	"github.com/maverickkamal/Packet-Rush/internal/levels"
+	"github.com/maverickkamal/Packet-Rush/internal/types"
+)
+
+func TestBotBeatsTheBuiltinLevels(t *testing.T) {
+	seeds := []int64{1, 2, 3}
+	for level := 1; level <= types.MaxLevel; level++ {
+		report := CheckLevel(level, seeds, DefaultMaxTicks)
+		if !report.Beatable() {
+			t.Errorf("level %d: the bot lost with every seed: %+v", level, report.Results)
+		}
+	}
+}
+
+func TestPlayIsDeterministic(t *testing.T) {
+	a := Play(levels.NewSimulationForLevel(2, 7), DefaultMaxTicks)
+	b := Play(levels.NewSimulationForLevel(2, 7), DefaultMaxTicks)
+	if a != b {
+		t.Errorf("two solves of the same seed differ: %+v and %+v", a, b)
+	}
+	if a.Won && a.Lives <= 0 {
+		t.Errorf("won with %d lives left", a.Lives)
+	}
+}
@@ -0,0 +1,139 @@
+package daily
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+const dateFormat = "2006-01-02"
+
+// Result is how a day's challenge went. Only the first finished attempt of
+// a day is kept, so everyone's result is from the same first try.
+type Result struct {
+	Date      string `json:"date"`
+	Won       bool   `json:"won"`
+	Score     int    `json:"score"`
+	Ticks     int    `json:"ticks"`
+	Misroutes int    `json:"misroutes"`
+}
+
+// Share is the result as one line to paste into chat. It leaves out the
+// word so it doesn't spoil the challenge for anyone else.
+func (r Result) Share() string {
+	mark := "✅"
+	if !r.Won {
+		mark = "❌"
+	}
+	misroutes := "misroutes"
+	if r.Misroutes == 1 {
+		misroutes = "misroute"
+	}
+	return fmt.Sprintf("Packet Rush Daily %s %s %d pts │ %d ticks │ %d %s", r.Date, mark, r.Score, r.Ticks, r.Misroutes, misroutes)
+}
+
+// Date is the challenge date for t. Days run in UTC so that players in
+// every timezone are on the same challenge at the same moment.
+func Date(t time.Time) string {
+	return t.UTC().Format(dateFormat)
+}
+
+// Seed is the seed for the challenge on t's UTC date, the date written as a
+// number such as 20261018, so everyone gets the same layout, word and
+// packets that day.
+func Seed(t time.Time) int64 {
+	t = t.UTC()
+	return int64(t.Year()*10000 + int(t.Month())*100 + t.Day())
+}
+
+// SeedDate turns a seed from Seed back into its date.
+func SeedDate(seed int64) string {
+	return fmt.Sprintf("%04d-%02d-%02d", seed/10000, seed/100%100, seed%100)
+}
+
+type History struct {
+	Results []Result `json:"results"`
+
+	path string
+}
+
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "packet-rush", "daily.json"), nil
+}
+
+// Load reads the history at path. A missing file is an empty history.
+func Load(path string) (*History, error) {
+	h := &History{path: path}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return h, nil
+	}
+	if err != nil {
+		return h, err
+	}
+	if err := json.Unmarshal(data, h); err != nil {
+		return h, err
+	}
+	return h, nil
+}
+
+func (h *History) Save() error {
+	if err := os.MkdirAll(filepath.Dir(h.path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(h, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(h.path, data, 0o644)
+}
+
+// Get returns the result recorded for date, if there is one.
+func (h *History) Get(date string) (Result, bool) {
+	for _, r := range h.Results {
+		if r.Date == date {
+			return r, true
+		}
+	}
+	return Result{}, false
+}
+
+// Add records r unless its day already has a result, and reports whether it
+// did. Results are kept newest first.
+func (h *History) Add(r Result) bool {
+	if _, exists := h.Get(r.Date); exists {
+		return false
+	}
+	h.Results = append(h.Results, r)
+	sort.SliceStable(h.Results, func(i, j int) bool {
+		return h.Results[i].Date > h.Results[j].Date
+	})
+	return true
+}
+
+// Streak is how many days in a row up to date have a won result.
+func (h *History) Streak(date string) int {
+	day, err := time.Parse(dateFormat, date)
+	if err != nil {
+		return 0
+	}
+	streak := 0
+	for {
+		r, ok := h.Get(day.Format(dateFormat))
+		if !ok || !r.Won {
+			return streak
+		}
+		streak++
+		day = day.AddDate(0, 0, -1)
+	}
+}
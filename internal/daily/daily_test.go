@@ -0,0 +1,64 @@
+package daily
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSeedIsTheSameInEveryTimezone(t *testing.T) {
+	instant := time.Date(2026, 10, 18, 23, 30, 0, 0, time.UTC)
+	tokyo := instant.In(time.FixedZone("JST", 9*60*60))
+	honolulu := instant.In(time.FixedZone("HST", -10*60*60))
+
+	for _, local := range []time.Time{tokyo, honolulu} {
+		if got := Seed(local); got != 20261018 {
+			t.Errorf("Seed(%v) = %d, want 20261018", local, got)
+		}
+		if got := Date(local); got != "2026-10-18" {
+			t.Errorf("Date(%v) = %s, want 2026-10-18", local, got)
+		}
+	}
+	if got := SeedDate(Seed(instant)); got != Date(instant) {
+		t.Errorf("SeedDate(Seed) = %s, want %s", got, Date(instant))
+	}
+}
+
+func TestShare(t *testing.T) {
+	r := Result{Date: "2026-10-18", Won: true, Score: 340, Ticks: 123, Misroutes: 1}
+	want := "Packet Rush Daily 2026-10-18 ✅ 340 pts │ 123 ticks │ 1 misroute"
+	if got := r.Share(); got != want {
+		t.Errorf("Share() = %q, want %q", got, want)
+	}
+}
+
+func TestHistoryKeepsFirstTryAndCountsStreak(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "daily.json")
+	h, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h.Add(Result{Date: "2026-10-16", Won: true})
+	h.Add(Result{Date: "2026-10-17", Won: true, Score: 10})
+	h.Add(Result{Date: "2026-10-18", Won: true})
+	if h.Add(Result{Date: "2026-10-17", Won: false, Score: 99}) {
+		t.Error("a second result for the same day was kept")
+	}
+	if err := h.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r, _ := loaded.Get("2026-10-17"); r.Score != 10 {
+		t.Errorf("2026-10-17 score = %d, want the first try's 10", r.Score)
+	}
+	if got := loaded.Streak("2026-10-18"); got != 3 {
+		t.Errorf("Streak = %d, want 3", got)
+	}
+	if loaded.Results[0].Date != "2026-10-18" {
+		t.Errorf("results aren't newest first: %v", loaded.Results)
+	}
+}
@@ -0,0 +1,393 @@
+package editor
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/maverickkamal/Packet-Rush/internal/levels"
+	"github.com/maverickkamal/Packet-Rush/internal/types"
+)
+
+const (
+	DefaultWidth  = 80
+	DefaultHeight = 20
+)
+
+type mode int
+
+const (
+	modePaint mode = iota
+	modeJunction
+	modeField
+	modePlay
+)
+
+type field int
+
+const (
+	fieldWord field = iota
+	fieldGoal
+	fieldInterval
+	fieldLevel
+)
+
+// junctionSpec is a junction the author has set up by hand. Its first
+// direction is the one active when the level starts; a zero key means the
+// junction isn't switched from the keyboard.
+type junctionSpec struct {
+	directions []types.Position
+	key        rune
+}
+
+// Editor is a Bubble Tea model for painting levels and saving them in the
+// external level format. Junctions are detected from the grid like any
+// level file without a junction list, until the author sets one up by hand.
+type Editor struct {
+	path     string
+	level    int
+	grid     [][]rune
+	word     string
+	goal     string
+	interval string
+	ordered  bool
+	spawners []levels.SpawnerFile
+	table    *levels.SpawnTableFile
+
+	// collisions, capacity and the TTL are kept as the file has them.
+	collisions string
+	capacity   int
+	ttl        int
+	ttlHops    bool
+
+	// junctions holds the hand-set junctions. Once there are any, the saved
+	// file lists every junction instead of leaving them to be detected.
+	junctions map[types.Position]*junctionSpec
+
+	x, y  int
+	brush rune
+
+	mode   mode
+	field  field
+	input  []rune
+	issues []levels.Issue
+
+	play    *types.GameModel
+	playGen int
+
+	message     string
+	dirty       bool
+	confirmQuit bool
+
+	width, height int
+	offsetX       int
+	offsetY       int
+}
+
+// playMsg carries a message for the play-test model, tagged so ticks from a
+// play-test that has since ended are dropped.
+type playMsg struct {
+	gen int
+	msg tea.Msg
+}
+
+// New starts an empty level of the given size, walled in.
+func New(path string, level, width, height int) *Editor {
+	e := &Editor{
+		path:      path,
+		level:     level,
+		interval:  types.InitialSpawnInterval.String(),
+		junctions: make(map[types.Position]*junctionSpec),
+		brush:     '#',
+		x:         1,
+		y:         1,
+	}
+
+	e.grid = make([][]rune, height)
+	for y := range e.grid {
+		e.grid[y] = make([]rune, width)
+		for x := range e.grid[y] {
+			if y == 0 || y == height-1 || x == 0 || x == width-1 {
+				e.grid[y][x] = '#'
+			} else {
+				e.grid[y][x] = ' '
+			}
+		}
+	}
+	return e
+}
+
+// Open loads the level at path, or starts a new one there if it doesn't
+// exist yet.
+func Open(path string, level, width, height int) (*Editor, error) {
+	file, err := levels.ReadLevelFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		e := New(path, level, width, height)
+		e.message = "New level - it will be saved to " + path
+		return e, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	e := &Editor{
+		path:       path,
+		level:      file.Level,
+		word:       file.TargetWord,
+		ordered:    file.Ordered,
+		spawners:   file.Spawners,
+		table:      file.SpawnTable,
+		collisions: file.Collisions,
+		capacity:   file.LinkCapacity,
+		ttl:        file.TTL,
+		ttlHops:    file.TTLHops,
+		goal:       file.Goal,
+		interval:   file.SpawnInterval,
+		junctions:  make(map[types.Position]*junctionSpec),
+		brush:      '#',
+	}
+	if e.level < 1 {
+		e.level = level
+	}
+	if e.interval == "" {
+		e.interval = types.InitialSpawnInterval.String()
+	}
+
+	// Rows are padded to the widest one so every row ends up the same width.
+	gridWidth := 0
+	for _, row := range file.Grid {
+		gridWidth = max(gridWidth, utf8.RuneCountInString(row))
+	}
+	for _, row := range file.Grid {
+		runes := []rune(row)
+		for len(runes) < gridWidth {
+			runes = append(runes, ' ')
+		}
+		e.grid = append(e.grid, runes)
+	}
+	if len(e.grid) == 0 {
+		e.grid = New(path, level, width, height).grid
+	}
+
+	for _, j := range file.Junctions {
+		spec := &junctionSpec{}
+		spec.key, _ = utf8.DecodeRuneInString(j.ID)
+		for _, name := range j.Directions {
+			dir, ok := types.ParseDirection(name)
+			if !ok {
+				return nil, fmt.Errorf("%s: junction at (%d,%d) has unknown direction %q", path, j.X, j.Y, name)
+			}
+			spec.directions = append(spec.directions, dir)
+		}
+		e.junctions[types.Position{X: j.X, Y: j.Y}] = spec
+	}
+
+	e.message = "Opened " + path
+	return e, nil
+}
+
+func (e *Editor) rows() []string {
+	rows := make([]string, len(e.grid))
+	for y, row := range e.grid {
+		rows[y] = string(row)
+	}
+	return rows
+}
+
+func (e *Editor) cell(x, y int) rune {
+	if y < 0 || y >= len(e.grid) || x < 0 || x >= len(e.grid[y]) {
+		return 0
+	}
+	return e.grid[y][x]
+}
+
+func (e *Editor) paint(x, y int, char rune) {
+	if e.cell(x, y) == 0 || e.grid[y][x] == char {
+		return
+	}
+	e.grid[y][x] = char
+	e.issues = nil
+	if char != '+' {
+		delete(e.junctions, types.Position{X: x, Y: y})
+	}
+	e.dirty = true
+}
+
+// effectiveJunctions is what the level will be played with: detected
+// junctions, overridden by the hand-set ones.
+func (e *Editor) effectiveJunctions() map[types.Position]*junctionSpec {
+	result := make(map[types.Position]*junctionSpec)
+	for _, j := range levels.ParseGrid(e.rows()) {
+		result[types.Position{X: j.X, Y: j.Y}] = &junctionSpec{directions: j.Directions, key: j.ID}
+	}
+	for pos, spec := range e.junctions {
+		if e.cell(pos.X, pos.Y) == '+' {
+			result[pos] = spec
+		}
+	}
+	return result
+}
+
+// LevelFile is the level as it will be saved.
+func (e *Editor) LevelFile() levels.LevelFile {
+	file := levels.LevelFile{
+		Path:          e.path,
+		Level:         e.level,
+		Grid:          e.rows(),
+		SpawnInterval: e.interval,
+		Goal:          e.goal,
+		TargetWord:    e.word,
+		Ordered:       e.ordered,
+		Spawners:      e.spawners,
+		SpawnTable:    e.table,
+		Collisions:    e.collisions,
+		LinkCapacity:  e.capacity,
+		TTL:           e.ttl,
+		TTLHops:       e.ttlHops,
+	}
+	if len(e.junctions) == 0 {
+		return file
+	}
+
+	effective := e.effectiveJunctions()
+	positions := make([]types.Position, 0, len(effective))
+	for pos := range effective {
+		positions = append(positions, pos)
+	}
+	sort.Slice(positions, func(a, b int) bool {
+		if positions[a].Y != positions[b].Y {
+			return positions[a].Y < positions[b].Y
+		}
+		return positions[a].X < positions[b].X
+	})
+
+	for _, pos := range positions {
+		spec := effective[pos]
+		j := levels.JunctionFile{X: pos.X, Y: pos.Y}
+		if spec.key != 0 {
+			j.ID = string(spec.key)
+		}
+		for _, dir := range spec.directions {
+			j.Directions = append(j.Directions, types.DirectionName(dir))
+		}
+		file.Junctions = append(file.Junctions, j)
+	}
+	return file
+}
+
+func (e *Editor) save() {
+	file := e.LevelFile()
+	if err := file.Save(e.path); err != nil {
+		e.message = fmt.Sprintf("Could not save: %v", err)
+		return
+	}
+	e.dirty = false
+	e.confirmQuit = false
+
+	data, err := file.LevelData()
+	switch {
+	case err != nil:
+		e.message = fmt.Sprintf("Saved %s, but it won't load yet: %v", e.path, err)
+	case levels.HasErrors(levels.Validate(data)):
+		e.message = fmt.Sprintf("Saved %s, but it isn't winnable yet - press Ctrl+E for details", e.path)
+	default:
+		e.message = "Saved " + e.path
+	}
+}
+
+// check builds the level and validates it, keeping the issues for display.
+func (e *Editor) check() (levels.LevelData, bool) {
+	data, err := e.LevelFile().LevelData()
+	if err != nil {
+		e.issues = []levels.Issue{{Message: err.Error()}}
+		return data, false
+	}
+	e.issues = levels.Validate(data)
+	return data, !levels.HasErrors(e.issues)
+}
+
+func (e *Editor) startPlayTest() tea.Cmd {
+	data, ok := e.check()
+	if !ok {
+		e.message = "Fix the errors below before play-testing"
+		return nil
+	}
+
+	e.playGen++
+	e.play = types.NewGameModel(levels.NewSimulation(data, e.level, time.Now().UnixNano()))
+	e.play.SetSize(e.width, e.height-1)
+	e.mode = modePlay
+	e.message = ""
+	return e.wrap(e.play.Init())
+}
+
+func (e *Editor) stopPlayTest(message string) {
+	e.play = nil
+	e.playGen++
+	e.mode = modePaint
+	e.message = message
+}
+
+func (e *Editor) wrap(cmd tea.Cmd) tea.Cmd {
+	if cmd == nil {
+		return nil
+	}
+	gen := e.playGen
+	return func() tea.Msg {
+		return playMsg{gen: gen, msg: cmd()}
+	}
+}
+
+func (e *Editor) startField(f field) {
+	e.mode = modeField
+	e.field = f
+	switch f {
+	case fieldWord:
+		e.input = []rune(e.word)
+	case fieldGoal:
+		e.input = []rune(e.goal)
+	case fieldInterval:
+		e.input = []rune(e.interval)
+	case fieldLevel:
+		e.input = []rune(fmt.Sprint(e.level))
+	}
+}
+
+func (e *Editor) commitField() {
+	value := strings.TrimSpace(string(e.input))
+	switch e.field {
+	case fieldWord:
+		word := strings.ToUpper(value)
+		for _, r := range word {
+			if r < 'A' || r > 'Z' {
+				e.message = "The target word can only use the letters A-Z"
+				return
+			}
+		}
+		e.word = word
+	case fieldGoal:
+		e.goal = value
+	case fieldInterval:
+		d, err := time.ParseDuration(value)
+		if err != nil || d <= 0 {
+			e.message = "Spawn interval must be a positive duration such as 4s or 3500ms"
+			return
+		}
+		e.interval = value
+	case fieldLevel:
+		var level int
+		if _, err := fmt.Sscan(value, &level); err != nil || level < 1 {
+			e.message = "Level number must be 1 or more"
+			return
+		}
+		e.level = level
+	}
+	e.dirty = true
+	e.mode = modePaint
+	e.message = ""
+}
@@ -0,0 +1,255 @@
+package editor
+
+import (
+	"strings"
+	"unicode"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/maverickkamal/Packet-Rush/internal/types"
+)
+
+// paintable is every character the brush can lay down besides letters.
+const paintable = "#-|+S* "
+
+func (e *Editor) Init() tea.Cmd {
+	return nil
+}
+
+func (e *Editor) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		e.width, e.height = msg.Width, msg.Height
+		if e.play != nil {
+			e.play.SetSize(msg.Width, msg.Height-1)
+		}
+		return e, nil
+
+	case playMsg:
+		if msg.gen != e.playGen || e.play == nil {
+			return e, nil
+		}
+		return e, e.updatePlay(msg.msg)
+	}
+
+	if e.mode == modePlay {
+		if key, ok := msg.(tea.KeyMsg); ok {
+			switch key.String() {
+			case "ctrl+c":
+				return e, tea.Quit
+			case "esc", "ctrl+p":
+				e.stopPlayTest("Play-test stopped")
+				return e, nil
+			}
+		}
+		return e, e.updatePlay(msg)
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch e.mode {
+		case modeJunction:
+			e.updateJunction(msg)
+			return e, nil
+		case modeField:
+			e.updateField(msg)
+			return e, nil
+		}
+		return e, e.updatePaint(msg)
+
+	case tea.MouseMsg:
+		if e.mode == modePaint {
+			e.handleMouse(msg)
+		}
+	}
+
+	return e, nil
+}
+
+func (e *Editor) updatePlay(msg tea.Msg) tea.Cmd {
+	_, cmd := e.play.Update(msg)
+
+	if e.play.NextLevelRequested {
+		e.stopPlayTest("Level complete - play-test passed")
+		return nil
+	}
+	if e.play.RestartRequested {
+		return e.startPlayTest()
+	}
+	return e.wrap(cmd)
+}
+
+func (e *Editor) updatePaint(key tea.KeyMsg) tea.Cmd {
+	if key.String() != "ctrl+q" && key.String() != "ctrl+c" {
+		e.confirmQuit = false
+	}
+
+	switch key.String() {
+	case "ctrl+c", "ctrl+q":
+		if e.dirty && !e.confirmQuit {
+			e.confirmQuit = true
+			e.message = "Unsaved changes - press Ctrl+Q again to quit without saving"
+			return nil
+		}
+		return tea.Quit
+	case "ctrl+s":
+		e.save()
+	case "ctrl+p":
+		return e.startPlayTest()
+	case "ctrl+e":
+		if _, ok := e.check(); ok {
+			e.message = "Level is winnable"
+		} else {
+			e.message = "Level has errors"
+		}
+	case "ctrl+w":
+		e.startField(fieldWord)
+	case "ctrl+g":
+		e.startField(fieldGoal)
+	case "ctrl+t":
+		e.startField(fieldInterval)
+	case "ctrl+n":
+		e.startField(fieldLevel)
+	case "enter":
+		e.startJunction()
+	case "up":
+		e.moveCursor(0, -1, false)
+	case "down":
+		e.moveCursor(0, 1, false)
+	case "left":
+		e.moveCursor(-1, 0, false)
+	case "right":
+		e.moveCursor(1, 0, false)
+	case "shift+up":
+		e.moveCursor(0, -1, true)
+	case "shift+down":
+		e.moveCursor(0, 1, true)
+	case "shift+left":
+		e.moveCursor(-1, 0, true)
+	case "shift+right":
+		e.moveCursor(1, 0, true)
+	case "backspace", "delete":
+		e.paint(e.x, e.y, ' ')
+	case " ":
+		e.brush = ' '
+		e.paint(e.x, e.y, ' ')
+	default:
+		if key.Type != tea.KeyRunes || len(key.Runes) != 1 {
+			return nil
+		}
+		char := unicode.ToUpper(key.Runes[0])
+		if (char < 'A' || char > 'Z') && !strings.ContainsRune(paintable, char) {
+			return nil
+		}
+		e.brush = char
+		e.paint(e.x, e.y, char)
+	}
+	return nil
+}
+
+// moveCursor steps the cursor, painting the cell it lands on with the brush
+// when drawing so lines can be laid down with Shift+arrows.
+func (e *Editor) moveCursor(dx, dy int, draw bool) {
+	x, y := e.x+dx, e.y+dy
+	if e.cell(x, y) == 0 {
+		return
+	}
+	e.x, e.y = x, y
+	if draw {
+		e.paint(x, y, e.brush)
+	}
+}
+
+func (e *Editor) handleMouse(msg tea.MouseMsg) {
+	x, y, ok := e.cellAtScreen(msg.X, msg.Y)
+	if !ok {
+		return
+	}
+
+	switch {
+	case msg.Button == tea.MouseButtonLeft && (msg.Action == tea.MouseActionPress || msg.Action == tea.MouseActionMotion):
+		e.x, e.y = x, y
+		e.paint(x, y, e.brush)
+	case msg.Button == tea.MouseButtonRight && (msg.Action == tea.MouseActionPress || msg.Action == tea.MouseActionMotion):
+		e.x, e.y = x, y
+		e.paint(x, y, ' ')
+	}
+}
+
+func (e *Editor) startJunction() {
+	if e.cell(e.x, e.y) != '+' {
+		e.message = "Put the cursor on a + to set up a junction"
+		return
+	}
+
+	pos := types.Position{X: e.x, Y: e.y}
+	if _, exists := e.junctions[pos]; !exists {
+		spec := &junctionSpec{}
+		if detected, ok := e.effectiveJunctions()[pos]; ok {
+			spec.key = detected.key
+			spec.directions = append(spec.directions, detected.directions...)
+		}
+		e.junctions[pos] = spec
+		e.dirty = true
+	}
+	e.mode = modeJunction
+	e.message = ""
+}
+
+func (e *Editor) updateJunction(key tea.KeyMsg) {
+	pos := types.Position{X: e.x, Y: e.y}
+	spec := e.junctions[pos]
+
+	switch key.String() {
+	case "enter", "esc", "ctrl+c":
+		e.mode = modePaint
+	case "up":
+		spec.toggle(types.Up)
+	case "down":
+		spec.toggle(types.Down)
+	case "left":
+		spec.toggle(types.Left)
+	case "right":
+		spec.toggle(types.Right)
+	case "backspace":
+		spec.key = 0
+	case "delete":
+		// Hand the junction back to detection.
+		delete(e.junctions, pos)
+		e.mode = modePaint
+		e.message = "Junction reset to the detected settings"
+	default:
+		if key.Type == tea.KeyRunes && len(key.Runes) == 1 {
+			r := key.Runes[0]
+			if types.IsJunctionKey(r) {
+				spec.key = r
+			}
+		}
+	}
+	e.dirty = true
+}
+
+// toggle adds dir as the last choice, or removes it if already allowed.
+func (s *junctionSpec) toggle(dir types.Position) {
+	for i, d := range s.directions {
+		if d == dir {
+			s.directions = append(s.directions[:i], s.directions[i+1:]...)
+			return
+		}
+	}
+	s.directions = append(s.directions, dir)
+}
+
+func (e *Editor) updateField(key tea.KeyMsg) {
+	switch key.Type {
+	case tea.KeyEsc, tea.KeyCtrlC:
+		e.mode = modePaint
+	case tea.KeyEnter:
+		e.commitField()
+	case tea.KeyBackspace:
+		if len(e.input) > 0 {
+			e.input = e.input[:len(e.input)-1]
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		e.input = append(e.input, key.Runes...)
+	}
+}
@@ -0,0 +1,223 @@
+package editor
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/maverickkamal/Packet-Rush/internal/types"
+)
+
+const (
+	// gridTop is the screen row the first visible grid row is drawn on.
+	gridTop = 2
+
+	// panelLines is the most lines drawn below the grid.
+	panelLines = 12
+
+	maxIssues = 4
+)
+
+var directionArrows = map[types.Position]string{
+	types.Up:    "↑",
+	types.Down:  "↓",
+	types.Left:  "←",
+	types.Right: "→",
+}
+
+// visibleSize is how much of the grid fits on screen.
+func (e *Editor) visibleSize() (cols, rows int) {
+	cols, rows = len(e.grid[0]), len(e.grid)
+	if e.width > 0 {
+		cols = min(cols, e.width)
+	}
+	if e.height > 0 {
+		rows = max(1, min(rows, e.height-gridTop-panelLines))
+	}
+	return cols, rows
+}
+
+// scrollToCursor keeps the cursor inside the visible part of the grid.
+func (e *Editor) scrollToCursor() {
+	cols, rows := e.visibleSize()
+	if e.x < e.offsetX {
+		e.offsetX = e.x
+	} else if e.x >= e.offsetX+cols {
+		e.offsetX = e.x - cols + 1
+	}
+	if e.y < e.offsetY {
+		e.offsetY = e.y
+	} else if e.y >= e.offsetY+rows {
+		e.offsetY = e.y - rows + 1
+	}
+	e.offsetX = max(0, min(e.offsetX, len(e.grid[0])-cols))
+	e.offsetY = max(0, min(e.offsetY, len(e.grid)-rows))
+}
+
+func (e *Editor) cellAtScreen(col, row int) (x, y int, ok bool) {
+	cols, rows := e.visibleSize()
+	if col < 0 || col >= cols || row < gridTop || row >= gridTop+rows {
+		return 0, 0, false
+	}
+	return col + e.offsetX, row - gridTop + e.offsetY, true
+}
+
+func (e *Editor) View() string {
+	if e.mode == modePlay && e.play != nil {
+		return e.play.View() + types.ColorWhite + "Play-testing - " + types.ColorYellow + "[ESC]" + types.ColorWhite + " Back to the editor │ " + types.ColorYellow + "[R]" + types.ColorWhite + " Restart when over" + types.ColorReset + "\n"
+	}
+
+	e.scrollToCursor()
+	junctions := e.effectiveJunctions()
+
+	var builder strings.Builder
+	title := "🛠️  PACKET RUSH LEVEL EDITOR - " + e.path
+	if e.dirty {
+		title += " (modified)"
+	}
+	builder.WriteString(types.ColorCyan + types.ColorBright + title + types.ColorReset + "\n\n")
+
+	cols, rows := e.visibleSize()
+	for y := e.offsetY; y < e.offsetY+rows; y++ {
+		for x := e.offsetX; x < e.offsetX+cols; x++ {
+			builder.WriteString(e.renderCell(x, y, junctions))
+		}
+		builder.WriteString("\n")
+	}
+
+	builder.WriteString(fmt.Sprintf(types.ColorYellow+"Level %d"+types.ColorReset+" │ Word: "+types.ColorGreen+"%s"+types.ColorReset+
+		" │ Spawn every "+types.ColorCyan+"%s"+types.ColorReset+" │ Cursor (%d,%d) │ Brush '%c'\n",
+		e.level, orNone(e.word), e.interval, e.x, e.y, e.brush))
+	builder.WriteString(types.ColorMagenta + "Goal: " + types.ColorReset + orNone(e.goal) + "\n")
+
+	switch e.mode {
+	case modeField:
+		labels := map[field]string{
+			fieldWord:     "Target word",
+			fieldGoal:     "Goal text",
+			fieldInterval: "Spawn interval",
+			fieldLevel:    "Level number",
+		}
+		builder.WriteString(types.BgCyan + types.ColorBlack + " " + labels[e.field] + ": " + string(e.input) + "_ " + types.ColorReset + "\n")
+	default:
+		builder.WriteString(e.describeJunction(junctions) + "\n")
+	}
+
+	if e.message != "" {
+		builder.WriteString(types.ColorYellow + e.message + types.ColorReset + "\n")
+	}
+	for i, issue := range e.issues {
+		if i == maxIssues {
+			builder.WriteString(fmt.Sprintf("  ...and %d more\n", len(e.issues)-maxIssues))
+			break
+		}
+		color := types.ColorRed
+		if issue.Warning {
+			color = types.ColorYellow
+		}
+		builder.WriteString("  " + color + issue.String() + types.ColorReset + "\n")
+	}
+
+	builder.WriteString(e.renderHelp())
+	return builder.String()
+}
+
+func (e *Editor) renderCell(x, y int, junctions map[types.Position]*junctionSpec) string {
+	char := e.grid[y][x]
+	style := ""
+
+	switch {
+	case char == '#':
+		style = types.ColorBlue
+	case char == 'S':
+		style = types.BgGreen + types.ColorWhite
+	case char == types.Sink:
+		style = types.BgRed + types.ColorWhite + types.ColorBright
+	case char == '+':
+		style = types.ColorMagenta + types.ColorBright
+		if spec, ok := junctions[types.Position{X: x, Y: y}]; ok && spec.key != 0 {
+			char = spec.key
+		}
+	case char == '-' || char == '|':
+		style = types.ColorWhite
+	case types.IsLetterDestination(char):
+		style = types.BgMagenta + types.ColorWhite + types.ColorBright
+	}
+
+	if x == e.x && y == e.y {
+		style = types.BgYellow + types.ColorBlack + types.ColorBright
+		if e.mode == modeJunction {
+			style = types.BgCyan + types.ColorBlack + types.ColorBright
+		}
+	}
+	if style == "" {
+		return string(char)
+	}
+	return style + string(char) + types.ColorReset
+}
+
+func (e *Editor) describeJunction(junctions map[types.Position]*junctionSpec) string {
+	pos := types.Position{X: e.x, Y: e.y}
+	spec, ok := junctions[pos]
+	if !ok {
+		if e.cell(e.x, e.y) == '+' {
+			return types.ColorWhite + "Plain track crossing - press ENTER to make it a junction" + types.ColorReset
+		}
+		return ""
+	}
+
+	key := "none (mouse/cursor only)"
+	if spec.key != 0 {
+		key = string(spec.key)
+	}
+	arrows := make([]string, 0, len(spec.directions))
+	for _, dir := range spec.directions {
+		arrows = append(arrows, directionArrows[dir])
+	}
+	source := "detected"
+	if _, custom := e.junctions[pos]; custom {
+		source = "hand-set"
+	}
+
+	text := fmt.Sprintf("Junction (%d,%d), %s: key %s │ routes %s", e.x, e.y, source, key, strings.Join(arrows, " "))
+	if e.mode == modeJunction {
+		return types.BgCyan + types.ColorBlack + " " + text + " " + types.ColorReset
+	}
+	return types.ColorMagenta + text + types.ColorReset
+}
+
+func (e *Editor) renderHelp() string {
+	var builder strings.Builder
+	switch e.mode {
+	case modeJunction:
+		builder.WriteString(types.ColorWhite + "Junction: " + types.ColorGreen + "[←↑↓→]" + types.ColorWhite + " Toggle route (first is active at start) │ " +
+			types.ColorGreen + "[1-9]" + types.ColorWhite + " Key │ " +
+			types.ColorGreen + "[BKSP]" + types.ColorWhite + " No key" + types.ColorReset + "\n")
+		builder.WriteString(types.ColorWhite + "          " + types.ColorGreen + "[DEL]" + types.ColorWhite + " Back to detected │ " +
+			types.ColorGreen + "[ENTER/ESC]" + types.ColorWhite + " Done" + types.ColorReset + "\n")
+	case modeField:
+		builder.WriteString(types.ColorWhite + "Edit: " + types.ColorGreen + "[ENTER]" + types.ColorWhite + " Apply │ " +
+			types.ColorGreen + "[ESC]" + types.ColorWhite + " Cancel" + types.ColorReset + "\n")
+	default:
+		builder.WriteString(types.ColorWhite + "Paint: " + types.ColorGreen + "[# - | + S * A-Z]" + types.ColorWhite + " Draw │ " +
+			types.ColorGreen + "[SPACE]" + types.ColorWhite + " Erase │ " +
+			types.ColorGreen + "[←↑↓→]" + types.ColorWhite + " Move │ " +
+			types.ColorGreen + "[SHIFT+←↑↓→]" + types.ColorWhite + " Draw line" + types.ColorReset + "\n")
+		builder.WriteString(types.ColorWhite + "Level: " + types.ColorCyan + "[ENTER]" + types.ColorWhite + " Junction │ " +
+			types.ColorCyan + "[^W]" + types.ColorWhite + " Word │ " +
+			types.ColorCyan + "[^G]" + types.ColorWhite + " Goal │ " +
+			types.ColorCyan + "[^T]" + types.ColorWhite + " Interval │ " +
+			types.ColorCyan + "[^N]" + types.ColorWhite + " Number" + types.ColorReset + "\n")
+		builder.WriteString(types.ColorWhite + "File:  " + types.ColorYellow + "[^P]" + types.ColorWhite + " Play-test │ " +
+			types.ColorYellow + "[^E]" + types.ColorWhite + " Check │ " +
+			types.ColorYellow + "[^S]" + types.ColorWhite + " Save │ " +
+			types.ColorRed + "[^Q]" + types.ColorWhite + " Quit" + types.ColorReset + "\n")
+	}
+	return builder.String()
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "(none)"
+	}
+	return s
+}
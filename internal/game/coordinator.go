@@ -1,14 +1,51 @@
 package game
 
 import (
+	"fmt"
+
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/maverickkamal/Packet-Rush/internal/daily"
 	"github.com/maverickkamal/Packet-Rush/internal/levels"
+	"github.com/maverickkamal/Packet-Rush/internal/replay"
+	"github.com/maverickkamal/Packet-Rush/internal/savegame"
+	"github.com/maverickkamal/Packet-Rush/internal/scores"
 	"github.com/maverickkamal/Packet-Rush/internal/types"
 )
 
 
 type GameCoordinator struct {
 	Model *types.GameModel
+
+	// Recorder, when set, captures every level played into a replay.
+	Recorder *replay.Recorder
+
+	// Scores, when set, receives level bests and leaderboard entries.
+	Scores *scores.Table
+
+	// SavePath, when set, is where SaveProgress keeps the game in progress.
+	SavePath string
+
+	// Daily, when set, keeps the daily challenge results.
+	Daily *daily.History
+
+	// continued is the save the next model was restored from, for the
+	// recorder to start its replay from.
+	continued *savegame.SaveGame
+
+	levelStartScore int
+	levelRecorded   bool
+	newLevelBest    bool
+	bestSaveErr     error
+	runRecorded     bool
+
+	scoreboard *scoreboard
+	menu       *menu
+
+	daily      *dailyRun
+	dailyShare string
+
+	// width and height are the terminal size, handed to each new model.
+	width, height int
 }
 
 
@@ -20,37 +57,188 @@ func NewGameCoordinator() *GameCoordinator {
 
 
 func (gc *GameCoordinator) Init() tea.Cmd {
+	if gc.menu != nil {
+		return nil
+	}
+	gc.begin()
 	return gc.Model.Init()
 }
 
+// begin resets the per-level bookkeeping once a new model is in place and
+// any carried-over score has been set.
+func (gc *GameCoordinator) begin() {
+	gc.levelStartScore = gc.Model.Score
+	gc.levelRecorded = false
+	gc.newLevelBest = false
+	gc.bestSaveErr = nil
+	gc.runRecorded = false
+	gc.daily = nil
+	gc.Model.SetSize(gc.width, gc.height)
+
+	if gc.Recorder != nil && gc.continued != nil {
+		gc.Recorder.AttachContinued(gc.Model.Simulation, *gc.continued)
+	} else if gc.Recorder != nil {
+		gc.Recorder.Attach(gc.Model.Simulation)
+	}
+	gc.continued = nil
+}
+
 
 func (gc *GameCoordinator) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if size, ok := msg.(tea.WindowSizeMsg); ok {
+		gc.width, gc.height = size.Width, size.Height
+	}
+
+	if gc.menu != nil {
+		if key, ok := msg.(tea.KeyMsg); ok {
+			return gc, gc.updateMenu(key)
+		}
+		return gc, nil
+	}
+
+	if gc.scoreboard != nil {
+		switch msg := msg.(type) {
+		case tea.KeyMsg:
+			return gc, gc.updateScoreboard(msg)
+		case tea.MouseMsg:
+			return gc, nil
+		}
+	}
+
+	if key, ok := msg.(tea.KeyMsg); ok {
+		if key.String() == "tab" && gc.Scores != nil {
+			gc.openScoreboard(-1)
+			return gc, nil
+		}
+	}
+
 	model, cmd := gc.Model.Update(msg)
 	gc.Model = model.(*types.GameModel)
 
-	if gc.Model.RestartRequested {
+	gc.checkRecords()
+	gc.recordDaily()
+
+	// The autopilot stays on across levels so it can run as a demo.
+	autopilot := gc.Model.Autopilot
+
+	// The daily challenge is a single level, so going on plays it again. A
+	// failed puzzle is tried again rather than started over from the first.
+	if gc.Model.RestartRequested || (gc.Model.NextLevelRequested && gc.Model.Mode == types.ModeDaily) {
+		level := 1
+		if gc.Model.Mode == types.ModePuzzle {
+			level = gc.Model.Level
+		}
+		gc.Model = levels.NewGameModelForMode(gc.Model.Mode, level)
+		gc.Model.Autopilot = autopilot
+		gc.begin()
+		return gc, gc.Model.Init()
+	}
+
+	// The tutorial leads into the campaign.
+	if gc.Model.NextLevelRequested && gc.Model.Mode == types.ModeTutorial {
 		gc.Model = levels.NewGameModelForLevel(1)
-		gc.Model.RestartRequested = false
+		gc.begin()
 		return gc, gc.Model.Init()
 	}
 
 	if gc.Model.NextLevelRequested {
+		// Past the built-in levels the progression carries on with
+		// generated ones, so there is always a next level.
 		oldScore := gc.Model.Score
-		if gc.Model.Level < types.MaxLevel {
-			gc.Model = levels.NewGameModelForLevel(gc.Model.Level + 1)
-			gc.Model.Score = oldScore 
-		} else {
-			gc.Model = levels.NewGameModelForLevel(1)
-			gc.Model.Score = oldScore 
-		}
-		gc.Model.NextLevelRequested = false
+		gc.Model = levels.NewGameModelForMode(gc.Model.Mode, gc.Model.Level+1)
+		gc.Model.Score = oldScore
+		gc.Model.Autopilot = autopilot
+		gc.begin()
 		return gc, gc.Model.Init()
 	}
 
 	return gc, cmd
 }
 
+// checkRecords stores the level best when a level is won and offers a
+// leaderboard entry when the run ends. The progression and survival runs are
+// both endless, so that only happens on game over.
+func (gc *GameCoordinator) checkRecords() {
+	if gc.Scores == nil || gc.Model.AutopilotUsed {
+		return
+	}
+
+	m := gc.Model
+	// Level bests compare campaign runs only; a time attack scores a level
+	// differently.
+	if m.LevelComplete && !gc.levelRecorded && m.Mode == types.ModeCampaign {
+		gc.levelRecorded = true
+		gc.newLevelBest = gc.Scores.RecordLevel(m.Level, scores.LevelBest{
+			Score: m.Score - gc.levelStartScore,
+			Ticks: m.GameTime,
+			Lives: m.Lives,
+		})
+		if gc.newLevelBest {
+			gc.bestSaveErr = gc.Scores.Save()
+		}
+	}
+
+	// Puzzles are scored by switches rather than points.
+	if m.LevelComplete && !gc.levelRecorded && m.Mode == types.ModePuzzle {
+		gc.levelRecorded = true
+		gc.newLevelBest = gc.Scores.RecordPuzzle(m.Level, m.Switches)
+		if gc.newLevelBest {
+			gc.bestSaveErr = gc.Scores.Save()
+		}
+	}
+
+	// The daily challenge keeps its own history instead, and a puzzle only
+	// ends in a game over when it's failed.
+	if m.GameOver && !gc.runRecorded && m.Mode != types.ModeDaily && m.Mode != types.ModePuzzle && m.Mode != types.ModeTutorial {
+		gc.runRecorded = true
+		if gc.Scores.Qualifies(gc.board(), m.Score) {
+			gc.openNameEntry()
+		}
+	}
+}
+
+
+// SaveProgress stores the level in progress so it can be continued next
+// time, or clears the save once the run has ended.
+func (gc *GameCoordinator) SaveProgress() error {
+	// The tutorial's script isn't saved, so neither is the tutorial; any
+	// earlier save is left for next time.
+	if gc.SavePath == "" || gc.menu != nil || gc.Model.Mode == types.ModeTutorial {
+		return nil
+	}
+	// A finished level has nothing left to continue.
+	if gc.Model.Finished() {
+		return savegame.Remove(gc.SavePath)
+	}
+	save := savegame.Capture(gc.Model.Simulation, gc.levelStartScore)
+	save.AutopilotUsed = gc.Model.AutopilotUsed
+	return save.Save(gc.SavePath)
+}
+
 
 func (gc *GameCoordinator) View() string {
-	return gc.Model.View()
+	if gc.menu != nil {
+		return gc.renderMenu()
+	}
+
+	if gc.scoreboard != nil {
+		return gc.renderScoreboard()
+	}
+
+	if gc.daily != nil {
+		return gc.renderDaily()
+	}
+
+	view := gc.Model.View()
+	if gc.newLevelBest && gc.Model.LevelComplete {
+		text := "🏅 New best result for this level!"
+		if gc.Model.Mode == types.ModePuzzle {
+			text = "🏅 Fewest switches yet for this puzzle!"
+		}
+		view += types.ColorYellow + types.ColorBright + text + types.ColorReset + "\n"
+		if gc.bestSaveErr != nil {
+			view += types.ColorRed + fmt.Sprintf("Could not save scores: %v", gc.bestSaveErr) + types.ColorReset + "\n"
+		}
+	}
+	return view
 }
@@ -0,0 +1,56 @@
+package game
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/maverickkamal/Packet-Rush/internal/savegame"
+)
+
+func TestSaveProgressOnlyKeepsUnfinishedLevels(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "savegame.json")
+	gc := NewGameCoordinator()
+	gc.SavePath = path
+
+	if err := gc.SaveProgress(); err != nil {
+		t.Fatal(err)
+	}
+	if save, err := savegame.Load(path); save == nil || err != nil {
+		t.Fatalf("a level in progress wasn't saved: %v", err)
+	}
+
+	gc.Model.LevelComplete = true
+	if err := gc.SaveProgress(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("a finished level was left saved to continue")
+	}
+}
+
+func TestContinuingKeepsTheAutopilotMark(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "savegame.json")
+	gc := NewGameCoordinator()
+	gc.SavePath = path
+	gc.Model.AutopilotUsed = true
+	if err := gc.SaveProgress(); err != nil {
+		t.Fatal(err)
+	}
+
+	save, err := savegame.Load(path)
+	if err != nil || save == nil {
+		t.Fatalf("loading the save: %v", err)
+	}
+	resumed := NewGameCoordinator()
+	resumed.ShowMenu(save)
+	resumed.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if resumed.menu != nil {
+		t.Fatalf("continuing failed: %v", resumed.menu.err)
+	}
+	if !resumed.Model.AutopilotUsed {
+		t.Error("a level the autopilot played counts for records again after continuing it")
+	}
+}
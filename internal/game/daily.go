@@ -0,0 +1,120 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/maverickkamal/Packet-Rush/internal/daily"
+	"github.com/maverickkamal/Packet-Rush/internal/types"
+)
+
+// recentDays is how much of the daily history the result screen lists.
+const recentDays = 7
+
+// dailyRun is what is known about the daily challenge just played.
+type dailyRun struct {
+	result   daily.Result
+	recorded bool // the result is the day's first and went into the history
+	saveErr  error
+}
+
+// recordDaily keeps the result of a finished daily challenge. Only the first
+// finished attempt of a day goes into the history; later ones are practice.
+func (gc *GameCoordinator) recordDaily() {
+	m := gc.Model
+	if m.Mode != types.ModeDaily || !m.Finished() || gc.daily != nil {
+		return
+	}
+
+	gc.daily = &dailyRun{result: daily.Result{
+		Date:      daily.SeedDate(m.Seed),
+		Won:       m.LevelComplete,
+		Score:     m.Score,
+		Ticks:     m.GameTime,
+		Misroutes: m.Misroutes,
+	}}
+	if gc.Daily != nil && !m.AutopilotUsed && gc.Daily.Add(gc.daily.result) {
+		gc.daily.recorded = true
+		gc.daily.saveErr = gc.Daily.Save()
+	}
+	gc.dailyShare = gc.officialDaily(gc.daily.result.Date).Share()
+}
+
+// officialDaily is the result that counts for date: the first one in the
+// history, or the one just played if there is none.
+func (gc *GameCoordinator) officialDaily(date string) daily.Result {
+	if gc.Daily != nil {
+		if result, ok := gc.Daily.Get(date); ok {
+			return result
+		}
+	}
+	return gc.daily.result
+}
+
+// DailyShare is the share line for the daily challenge played this session,
+// or "" if there wasn't one.
+func (gc *GameCoordinator) DailyShare() string {
+	return gc.dailyShare
+}
+
+func (gc *GameCoordinator) dailyMenuLabel() string {
+	date := daily.Date(time.Now().UTC())
+	label := "Daily Challenge - " + date
+	if gc.Daily != nil {
+		if result, ok := gc.Daily.Get(date); ok {
+			label += fmt.Sprintf(" (done: %d pts, replay for practice)", result.Score)
+		}
+	}
+	return label
+}
+
+func (gc *GameCoordinator) renderDaily() string {
+	var builder strings.Builder
+	run := gc.daily
+	line := func(text, color string) {
+		builder.WriteString(gc.centered(text) + color + text + types.ColorReset + "\n")
+	}
+
+	builder.WriteString("\n\n")
+	line("📅 DAILY CHALLENGE "+run.result.Date+" 📅", titleStyle)
+	builder.WriteString("\n")
+
+	if run.result.Won {
+		line("✅ Word spelled!", types.ColorGreen+types.ColorBright)
+	} else {
+		line("❌ Network down", types.ColorRed+types.ColorBright)
+	}
+	line(fmt.Sprintf("This run: %d pts │ %d ticks │ %d misroutes", run.result.Score, run.result.Ticks, run.result.Misroutes), types.ColorYellow)
+	if !run.recorded {
+		line("Practice run - the day's first result is the one that counts", types.ColorWhite)
+	}
+	builder.WriteString("\n")
+
+	line("Share your result:", types.ColorCyan)
+	line(gc.dailyShare, types.ColorWhite+types.ColorBright)
+	builder.WriteString("\n")
+
+	if gc.Daily != nil {
+		if streak := gc.Daily.Streak(run.result.Date); streak > 1 {
+			line(fmt.Sprintf("🔥 %d days won in a row", streak), types.ColorYellow)
+			builder.WriteString("\n")
+		}
+
+		line("Recent days", types.ColorMagenta+types.ColorBright)
+		for i, result := range gc.Daily.Results {
+			if i == recentDays {
+				break
+			}
+			line(strings.TrimPrefix(result.Share(), "Packet Rush Daily "), types.ColorCyan)
+		}
+	}
+	if run.saveErr != nil {
+		builder.WriteString("\n")
+		line(fmt.Sprintf("Could not save the daily history: %v", run.saveErr), types.ColorRed)
+	}
+
+	builder.WriteString("\n")
+	line("[R] Play again for practice │ [Q] Quit", types.ColorWhite)
+	return builder.String()
+}
@@ -0,0 +1,148 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/maverickkamal/Packet-Rush/internal/levels"
+	"github.com/maverickkamal/Packet-Rush/internal/savegame"
+	"github.com/maverickkamal/Packet-Rush/internal/types"
+)
+
+type menuOption struct {
+	label string
+	start func(gc *GameCoordinator) tea.Cmd
+}
+
+type menu struct {
+	options  []menuOption
+	selected int
+	err      error
+}
+
+// ShowMenu puts a startup menu in front of the game for picking a mode, with
+// an option to resume save first if there is one.
+func (gc *GameCoordinator) ShowMenu(save *savegame.SaveGame) {
+	gc.menu = &menu{}
+
+	if save != nil {
+		label := fmt.Sprintf("Continue - Level %d, Score %d, Lives %d", save.Level, save.Score, save.Lives)
+		switch save.Mode {
+		case types.ModeSurvival:
+			label = fmt.Sprintf("Continue Survival - Wave %d, Score %d, Lives %d", save.WordsCompleted+1, save.Score, save.Lives)
+		case types.ModeTimeAttack:
+			label = fmt.Sprintf("Continue Time Attack - Level %d, Score %d, %ds left", save.Level, save.Score, int(save.TimeLeft.Seconds()))
+		case types.ModePuzzle:
+			label = fmt.Sprintf("Continue Puzzle %d - %d switches so far", save.Level, save.Switches)
+		}
+		gc.menu.options = append(gc.menu.options, menuOption{
+			label: label,
+			start: func(gc *GameCoordinator) tea.Cmd {
+				sim, err := save.Restore()
+				if err != nil {
+					gc.menu.err = err
+					return nil
+				}
+				gc.continued = save
+				model := types.NewGameModel(sim)
+				model.AutopilotUsed = save.AutopilotUsed
+				cmd := gc.start(model)
+				gc.levelStartScore = save.LevelStartScore
+				return cmd
+			},
+		})
+	}
+
+	gc.menu.options = append(gc.menu.options,
+		menuOption{
+			label: "Campaign",
+			start: func(gc *GameCoordinator) tea.Cmd {
+				return gc.start(levels.NewGameModelForLevel(1))
+			},
+		},
+		menuOption{
+			label: "Tutorial - learn to route packets step by step",
+			start: func(gc *GameCoordinator) tea.Cmd {
+				return gc.start(levels.NewGameModelForMode(types.ModeTutorial, 1))
+			},
+		},
+		menuOption{
+			label: "Survival - endless words, one run",
+			start: func(gc *GameCoordinator) tea.Cmd {
+				return gc.start(levels.NewGameModelForMode(types.ModeSurvival, 1))
+			},
+		},
+		menuOption{
+			label: gc.dailyMenuLabel(),
+			start: func(gc *GameCoordinator) tea.Cmd {
+				return gc.start(levels.NewGameModelForMode(types.ModeDaily, 1))
+			},
+		},
+		menuOption{
+			label: "Time Attack - beat the clock on every level",
+			start: func(gc *GameCoordinator) tea.Cmd {
+				return gc.start(levels.NewGameModelForMode(types.ModeTimeAttack, 1))
+			},
+		},
+		menuOption{
+			label: "Puzzle - set the junctions, then run a fixed queue",
+			start: func(gc *GameCoordinator) tea.Cmd {
+				return gc.start(levels.NewGameModelForMode(types.ModePuzzle, 1))
+			},
+		},
+	)
+}
+
+// start hands control to model, leaving the menu behind.
+func (gc *GameCoordinator) start(model *types.GameModel) tea.Cmd {
+	gc.menu = nil
+	gc.Model = model
+	gc.begin()
+	return gc.Model.Init()
+}
+
+func (gc *GameCoordinator) updateMenu(key tea.KeyMsg) tea.Cmd {
+	m := gc.menu
+
+	switch key.String() {
+	case "ctrl+c", "q":
+		return tea.Quit
+	case "up", "k":
+		m.selected = (m.selected + len(m.options) - 1) % len(m.options)
+	case "down", "j":
+		m.selected = (m.selected + 1) % len(m.options)
+	case "enter", " ":
+		return m.options[m.selected].start(gc)
+	}
+	return nil
+}
+
+func (gc *GameCoordinator) renderMenu() string {
+	var builder strings.Builder
+	m := gc.menu
+
+	builder.WriteString("\n\n")
+	title := "🚀 PACKET RUSH - Network Router Simulator 🚀"
+	builder.WriteString(gc.centered(title) + types.ColorCyan + types.ColorBright + title + types.ColorReset + "\n\n")
+
+	for i, option := range m.options {
+		line := "  " + option.label + "  "
+		color := types.ColorWhite
+		if i == m.selected {
+			line = "▶ " + option.label + "  "
+			color = types.BgCyan + types.ColorBlack + types.ColorBright
+		}
+		builder.WriteString(gc.centered(line) + color + line + types.ColorReset + "\n\n")
+	}
+
+	if m.err != nil {
+		text := fmt.Sprintf("Could not restore the saved game: %v", m.err)
+		builder.WriteString(gc.centered(text) + types.ColorRed + text + types.ColorReset + "\n\n")
+	}
+
+	controls := "[↑/↓] Select │ [ENTER] Start │ [Q] Quit"
+	builder.WriteString(gc.centered(controls) + types.ColorWhite + controls + types.ColorReset + "\n")
+
+	return builder.String()
+}
@@ -0,0 +1,239 @@
+package game
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/maverickkamal/Packet-Rush/internal/scores"
+	"github.com/maverickkamal/Packet-Rush/internal/types"
+)
+
+const (
+	maxNameLength = 12
+	titleStyle    = types.BgGold + types.ColorBlack + types.ColorBright
+)
+
+var boardTitles = map[string]string{
+	scores.BoardCampaign:   "Campaign",
+	scores.BoardSurvival:   "Survival",
+	scores.BoardTimeAttack: "Time Attack",
+}
+
+type scoreboard struct {
+	board        string
+	enteringName bool
+	name         []rune
+	highlight    int
+	pausedGame   bool
+	saveErr      error
+}
+
+// openScoreboard shows the leaderboard over the game, pausing a running
+// level until it is closed again.
+func (gc *GameCoordinator) openScoreboard(highlight int) {
+	sb := &scoreboard{board: gc.board(), highlight: highlight}
+	if !gc.Model.Paused && !gc.Model.Finished() {
+		gc.Model.Apply(types.Input{Kind: types.InputPause})
+		sb.pausedGame = true
+	}
+	gc.scoreboard = sb
+}
+
+func (gc *GameCoordinator) openNameEntry() {
+	gc.scoreboard = &scoreboard{board: gc.board(), enteringName: true, highlight: -1}
+}
+
+// board is the leaderboard for the kind of game being played.
+func (gc *GameCoordinator) board() string {
+	switch gc.Model.Mode {
+	case types.ModeSurvival:
+		return scores.BoardSurvival
+	case types.ModeTimeAttack:
+		return scores.BoardTimeAttack
+	}
+	return scores.BoardCampaign
+}
+
+func (gc *GameCoordinator) closeScoreboard() {
+	if gc.scoreboard.pausedGame {
+		gc.Model.Apply(types.Input{Kind: types.InputPause})
+	}
+	gc.scoreboard = nil
+}
+
+func (gc *GameCoordinator) updateScoreboard(key tea.KeyMsg) tea.Cmd {
+	sb := gc.scoreboard
+
+	if sb.enteringName {
+		switch key.Type {
+		case tea.KeyCtrlC:
+			return tea.Quit
+		case tea.KeyEsc:
+			gc.closeScoreboard()
+		case tea.KeyEnter:
+			name := strings.TrimSpace(string(sb.name))
+			if name == "" {
+				name = "Anonymous"
+			}
+			sb.highlight = gc.Scores.Add(sb.board, scores.Entry{
+				Name:  name,
+				Score: gc.Model.Score,
+				Level: gc.Model.Level,
+				Words: gc.Model.WordsCompleted,
+				Date:  time.Now(),
+			})
+			sb.saveErr = gc.Scores.Save()
+			sb.enteringName = false
+		case tea.KeyBackspace:
+			if len(sb.name) > 0 {
+				sb.name = sb.name[:len(sb.name)-1]
+			}
+		case tea.KeyRunes, tea.KeySpace:
+			for _, r := range key.Runes {
+				if len(sb.name) < maxNameLength {
+					sb.name = append(sb.name, r)
+				}
+			}
+		}
+		return nil
+	}
+
+	switch key.String() {
+	case "ctrl+c", "q":
+		return tea.Quit
+	case "tab", "esc", "enter", " ":
+		gc.closeScoreboard()
+	case "left", "h":
+		sb.showBoard(-1)
+	case "right", "l":
+		sb.showBoard(1)
+	}
+	return nil
+}
+
+// showBoard pages delta boards along scores.Boards.
+func (sb *scoreboard) showBoard(delta int) {
+	for i, board := range scores.Boards {
+		if board == sb.board {
+			sb.board = scores.Boards[(i+delta+len(scores.Boards))%len(scores.Boards)]
+			sb.highlight = -1
+			return
+		}
+	}
+}
+
+func (gc *GameCoordinator) centered(text string) string {
+	width := gc.width
+	if width == 0 {
+		width = 80
+	}
+	padding := (width - utf8.RuneCountInString(text)) / 2
+	if padding < 0 {
+		padding = 0
+	}
+	return strings.Repeat(" ", padding)
+}
+
+func (gc *GameCoordinator) renderScoreboard() string {
+	var builder strings.Builder
+	sb := gc.scoreboard
+
+	builder.WriteString("\n\n")
+
+	if sb.enteringName {
+		title := "🏆 NEW HIGH SCORE! 🏆"
+		builder.WriteString(gc.centered(title) + titleStyle + title + types.ColorReset + "\n\n")
+
+		scoreText := fmt.Sprintf("Score: %d │ Level: %d", gc.Model.Score, gc.Model.Level)
+		if sb.board == scores.BoardSurvival {
+			scoreText = fmt.Sprintf("Score: %d │ Words: %d", gc.Model.Score, gc.Model.WordsCompleted)
+		}
+		builder.WriteString(gc.centered(scoreText) + types.ColorYellow + scoreText + types.ColorReset + "\n\n")
+
+		prompt := "Enter your name: " + string(sb.name) + "_"
+		builder.WriteString(gc.centered(prompt) + types.ColorCyan + prompt + types.ColorReset + "\n\n")
+
+		controls := "[ENTER] Save │ [ESC] Skip"
+		builder.WriteString(gc.centered(controls) + types.ColorWhite + controls + types.ColorReset + "\n")
+		return builder.String()
+	}
+
+	title := "🏆 HIGH SCORES 🏆"
+	builder.WriteString(gc.centered(title) + titleStyle + title + types.ColorReset + "\n\n")
+
+	var tabs []string
+	for _, board := range scores.Boards {
+		name := boardTitles[board]
+		if board == sb.board {
+			name = "[" + name + "]"
+		}
+		tabs = append(tabs, name)
+	}
+	tabLine := strings.Join(tabs, "   ")
+	builder.WriteString(gc.centered(tabLine) + types.ColorCyan + types.ColorBright + tabLine + types.ColorReset + "\n\n")
+
+	entries := gc.Scores.Board(sb.board)
+	if len(entries) == 0 {
+		text := "No scores yet - go route some packets!"
+		builder.WriteString(gc.centered(text) + types.ColorWhite + text + types.ColorReset + "\n")
+	}
+	for i, entry := range entries {
+		line := fmt.Sprintf("%2d. %-12s %6d   Level %-3d %s", i+1, entry.Name, entry.Score, entry.Level, entry.Date.Format("2006-01-02"))
+		if sb.board == scores.BoardSurvival {
+			line = fmt.Sprintf("%2d. %-12s %6d   Words %-3d %s", i+1, entry.Name, entry.Score, entry.Words, entry.Date.Format("2006-01-02"))
+		}
+		color := types.ColorWhite
+		if i == sb.highlight {
+			color = types.BgYellow + types.ColorBlack + types.ColorBright
+		}
+		builder.WriteString(gc.centered(line) + color + line + types.ColorReset + "\n")
+	}
+
+	if sb.board == scores.BoardCampaign && len(gc.Scores.LevelBests) > 0 {
+		builder.WriteString("\n")
+		heading := "Level Bests"
+		builder.WriteString(gc.centered(heading) + types.ColorMagenta + types.ColorBright + heading + types.ColorReset + "\n")
+
+		levelNumbers := make([]int, 0, len(gc.Scores.LevelBests))
+		for level := range gc.Scores.LevelBests {
+			levelNumbers = append(levelNumbers, level)
+		}
+		sort.Ints(levelNumbers)
+		for _, level := range levelNumbers {
+			best := gc.Scores.LevelBests[level]
+			line := fmt.Sprintf("Level %-3d %5d pts │ %5d ticks │ %d lives left", level, best.Score, best.Ticks, best.Lives)
+			builder.WriteString(gc.centered(line) + types.ColorCyan + line + types.ColorReset + "\n")
+		}
+	}
+
+	if sb.board == scores.BoardCampaign && len(gc.Scores.PuzzleBests) > 0 {
+		builder.WriteString("\n")
+		heading := "Puzzle Bests"
+		builder.WriteString(gc.centered(heading) + types.ColorMagenta + types.ColorBright + heading + types.ColorReset + "\n")
+
+		puzzleNumbers := make([]int, 0, len(gc.Scores.PuzzleBests))
+		for puzzle := range gc.Scores.PuzzleBests {
+			puzzleNumbers = append(puzzleNumbers, puzzle)
+		}
+		sort.Ints(puzzleNumbers)
+		for _, puzzle := range puzzleNumbers {
+			line := fmt.Sprintf("Puzzle %-3d %3d switches", puzzle, gc.Scores.PuzzleBests[puzzle])
+			builder.WriteString(gc.centered(line) + types.ColorCyan + line + types.ColorReset + "\n")
+		}
+	}
+
+	if sb.saveErr != nil {
+		text := fmt.Sprintf("Could not save scores: %v", sb.saveErr)
+		builder.WriteString("\n" + gc.centered(text) + types.ColorRed + text + types.ColorReset + "\n")
+	}
+
+	builder.WriteString("\n")
+	controls := "[←/→] Board │ [TAB] Back │ [Q] Quit"
+	builder.WriteString(gc.centered(controls) + types.ColorWhite + controls + types.ColorReset + "\n")
+
+	return builder.String()
+}
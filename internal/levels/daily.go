@@ -0,0 +1,51 @@
+package levels
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/maverickkamal/Packet-Rush/internal/daily"
+	"github.com/maverickkamal/Packet-Rush/internal/types"
+)
+
+// dailyDifficulty is a mid-progression network: big enough to take some
+// thought, small enough to finish over a coffee.
+var dailyDifficulty = DifficultyForLevel(types.MaxLevel + 6)
+
+// DailyLevelData is the daily challenge network for seed, a date seed from
+// daily.Seed.
+func DailyLevelData(seed int64) (LevelData, error) {
+	date := daily.SeedDate(seed)
+	rng := rand.New(rand.NewSource(seed))
+	word := pickWord(rng, dailyDifficulty.Destinations)
+	letters := uniqueLetters(word)
+	decoys := pickDecoys(rng, letters, dailyDifficulty.Decoys)
+	labels := append(append([]rune{}, letters...), decoys...)
+
+	title := fmt.Sprintf("  DAILY CHALLENGE %s: Spell '%s'!", date, word)
+	grid, err := generateGrid(rng, dailyDifficulty, labels, title, word)
+	if err != nil {
+		return LevelData{}, err
+	}
+	return LevelData{
+		Grid:          grid,
+		Junctions:     ParseGrid(grid),
+		SpawnInterval: dailyDifficulty.SpawnInterval,
+		Goal:          fmt.Sprintf("Daily challenge for %s! Spell '%s' - everyone gets the same packets today.", date, word),
+		TargetWord:    word,
+	}, nil
+}
+
+// NewDailySimulation starts the daily challenge for seed. The packets are
+// drawn from the same seed as the layout, so the whole run is the same for
+// everyone playing that day.
+func NewDailySimulation(seed int64) *types.Simulation {
+	data, err := DailyLevelData(seed)
+	if err != nil {
+		// The difficulty is fixed and always fits, so this is a bug.
+		panic(fmt.Sprintf("generating the daily challenge for %s: %v", daily.SeedDate(seed), err))
+	}
+	sim := NewSimulation(data, 1, seed)
+	sim.Mode = types.ModeDaily
+	return sim
+}
@@ -0,0 +1,30 @@
+package levels
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDailyChallengeComesFromTheDate(t *testing.T) {
+	a, err := DailyLevelData(20261018)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := DailyLevelData(20261018)
+	if !reflect.DeepEqual(a.Grid, b.Grid) || a.TargetWord != b.TargetWord {
+		t.Error("the same date built two different daily challenges")
+	}
+	if issues := Validate(a); HasErrors(issues) {
+		t.Errorf("the daily challenge doesn't validate: %v", issues)
+	}
+
+	next, _ := DailyLevelData(20261019)
+	if reflect.DeepEqual(a.Grid, next.Grid) {
+		t.Error("two days got the same network")
+	}
+
+	sim := NewDailySimulation(20261018)
+	if sim.Seed != 20261018 || sim.TargetWord != a.TargetWord {
+		t.Errorf("the daily run isn't seeded from its date: seed %d, word %q", sim.Seed, sim.TargetWord)
+	}
+}
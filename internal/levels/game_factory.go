@@ -3,13 +3,46 @@ package levels
 import (
 	"time"
 
+	"github.com/maverickkamal/Packet-Rush/internal/daily"
 	"github.com/maverickkamal/Packet-Rush/internal/types"
 )
 
-func NewGameModelForLevel(level int) *types.GameModel {
-	levelData := GetLevelData(level)
+// NewSimulationForLevel builds a headless simulation whose clock only moves
+// with Step, so the same level and seed always play out identically.
+func NewSimulationForLevel(level int, seed int64) *types.Simulation {
+	return NewSimulation(GetLevelData(level), level, seed)
+}
+
+// spellInOrder makes every level count letters only in spelling order.
+var spellInOrder bool
+
+// SpellInOrder turns ordered spelling on for every level started from now
+// on, not just the ones that ask for it.
+func SpellInOrder() {
+	spellInOrder = true
+}
+
+// NewSimulation starts levelData as level number level, for levels that
+// aren't registered anywhere, such as one being play-tested in the editor.
+func NewSimulation(levelData LevelData, level int, seed int64) *types.Simulation {
+	clock := types.NewManualClock(time.Time{})
+
+	spawners := levelData.Spawners
+	if spawners == nil {
+		spawners = DetectSpawners(levelData.Grid)
+	}
+	// A grid with no usable S still gets packets where the first level's
+	// spawn is, so half-drawn levels can be play-tested.
+	if len(spawners) == 0 {
+		spawners = []types.Spawner{{X: 1, Y: 4, Dir: types.Right}}
+	}
+	running := make([]*types.Spawner, len(spawners))
+	for i, sp := range spawners {
+		sp.LastSpawn = clock.Now()
+		running[i] = &sp
+	}
 
-	return &types.GameModel{
+	return &types.Simulation{
 		Grid:          levelData.Grid,
 		Packets:       make([]*types.Packet, 0),
 		Junctions:     levelData.Junctions,
@@ -17,15 +50,60 @@ func NewGameModelForLevel(level int) *types.GameModel {
 		Lives:         types.LivesPerLevel,
 		Level:         level,
 		GameTime:      0,
-		LastSpawn:     time.Now(),
 		Paused:        false,
 		GameOver:      false,
 		LevelComplete: false,
+		Spawners:      running,
 		SpawnInterval: levelData.SpawnInterval,
+		SpawnTable:    levelData.SpawnTable,
+		Collisions:    levelData.Collisions,
+		LinkCapacity:  levelData.LinkCapacity,
+		TTL:           levelData.TTL,
+		TTLHops:       levelData.TTLHops,
 		TickSpeed:     types.InitialTickSpeed,
 		CurrentGoal:   levelData.Goal,
 		GoalProgress:  make([]rune, 0),
 		TargetWord:    levelData.TargetWord,
+		Ordered:       levelData.Ordered || spellInOrder,
+		Seed:          seed,
+		Clock:         clock,
+		Rand:          types.NewRand(seed),
 	}
 }
 
+// NewSimulationForMode starts level of the campaign in mode, or a survival
+// run, which has no levels.
+func NewSimulationForMode(mode types.Mode, level int, seed int64) *types.Simulation {
+	switch mode {
+	case types.ModeSurvival:
+		return NewSurvivalSimulation(seed)
+	case types.ModePuzzle:
+		return NewPuzzleSimulation(level, seed)
+	case types.ModeTutorial:
+		return NewTutorialSimulation(seed)
+	case types.ModeDaily:
+		return NewDailySimulation(seed)
+	case types.ModeTimeAttack:
+		sim := NewSimulationForLevel(level, seed)
+		sim.StartTimeAttack()
+		return sim
+	}
+	return NewSimulationForLevel(level, seed)
+}
+
+func NewGameModelForLevel(level int) *types.GameModel {
+	return types.NewGameModel(NewSimulationForLevel(level, time.Now().UnixNano()))
+}
+
+// NewGameModelForMode starts a game of mode with a fresh seed, or today's
+// seed for the daily challenge. The tutorial comes with its script.
+func NewGameModelForMode(mode types.Mode, level int) *types.GameModel {
+	seed := time.Now().UnixNano()
+	switch mode {
+	case types.ModeDaily:
+		seed = daily.Seed(time.Now().UTC())
+	case types.ModeTutorial:
+		return NewTutorialModel(seed)
+	}
+	return types.NewGameModel(NewSimulationForMode(mode, level, seed))
+}
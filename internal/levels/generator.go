@@ -0,0 +1,391 @@
+package levels
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/maverickkamal/Packet-Rush/internal/types"
+)
+
+// Difficulty describes the network Generate should build.
+type Difficulty struct {
+	// Width and Height are the grid size, walls included.
+	Width  int
+	Height int
+
+	// Junctions is how many switchable junctions to aim for. It is clamped
+	// to what Destinations, Decoys and Branching allow, since every route
+	// out of a junction has to end at a letter.
+	Junctions int
+
+	// Branching is the most routes out of a single junction, 2 or 3.
+	Branching int
+
+	// PathLength is the fewest track segments between the spawn and any
+	// letter.
+	PathLength int
+
+	// Destinations is how many different letters the target word has, and
+	// Decoys how many extra letters are on the map that aren't in it.
+	Destinations int
+	Decoys       int
+
+	SpawnInterval time.Duration
+}
+
+const (
+	// Nodes of the generated network sit on a lattice this far apart, so
+	// neighbouring tracks never touch.
+	latticeX = 4
+	latticeY = 2
+
+	// headerRows are the wall, title and spacing rows above the network.
+	// The first lattice row lines up with the spawn at (1,4).
+	headerRows = 4
+
+	generatorAttempts = 200
+)
+
+var generatorWords = []string{
+	"GO", "HI", "NET", "WEB", "LAN", "PING", "NODE", "CODE", "RUSH", "HOST",
+	"LINK", "PORT", "ROUTE", "CACHE", "PROXY", "SOCKET", "SERVER", "PACKET",
+	"ROUTER", "SWITCH", "GATEWAY", "NETWORK", "BACKBONE", "FIREWALL",
+	"PROTOCOL", "BANDWIDTH", "HANDSHAKE",
+}
+
+type latticeNode struct {
+	x, y int
+}
+
+type networkTree struct {
+	parent   map[latticeNode]latticeNode
+	children map[latticeNode][]latticeNode
+	depth    map[latticeNode]int
+}
+
+// DifficultyForLevel is the spec the endless progression uses for levels
+// past the built-in ones, getting a little harder every level.
+func DifficultyForLevel(level int) Difficulty {
+	n := max(1, level-types.MaxLevel)
+
+	d := Difficulty{
+		Width:         min(80+(n/4)*8, 120),
+		Height:        min(21+(n/3)*2, 41),
+		Branching:     2,
+		PathLength:    min(4+n/2, 12),
+		Destinations:  min(3+n/3, 8),
+		Decoys:        min(n/2, 5),
+		SpawnInterval: max(2*time.Second-time.Duration(n)*100*time.Millisecond, types.MinSpawnInterval),
+	}
+	if n >= 4 {
+		d.Branching = 3
+	}
+	// Later levels trade a few junctions for three-way ones.
+	routes := d.Destinations + d.Decoys - 1
+	d.Junctions = routes - min(n/6, routes/3)
+	return d
+}
+
+// GeneratedLevel is level number level of the endless progression. The seed
+// is the level number, so a level always has the same layout and level bests
+// stay comparable.
+func GeneratedLevel(level int) LevelData {
+	data, err := Generate(int64(level), DifficultyForLevel(level), level)
+	if err != nil {
+		// The progression's specs always fit, so this is a bug.
+		panic(fmt.Sprintf("generating level %d: %v", level, err))
+	}
+	return data
+}
+
+// Generate builds a network from seed: a tree of track rooted at the spawn
+// whose every branch ends at a letter, so every letter can be reached and
+// no route runs into a wall. The result is checked with Validate before it
+// is returned.
+func Generate(seed int64, d Difficulty, level int) (LevelData, error) {
+	rng := rand.New(rand.NewSource(seed))
+	word := pickWord(rng, d.Destinations)
+	letters := uniqueLetters(word)
+	decoys := pickDecoys(rng, letters, d.Decoys)
+	labels := append(append([]rune{}, letters...), decoys...)
+
+	title := fmt.Sprintf("  LEVEL %d: GENERATED NETWORK - Spell '%s'!", level, word)
+	grid, err := generateGrid(rng, d, labels, title, word)
+	if err != nil {
+		return LevelData{}, err
+	}
+
+	spawnInterval := d.SpawnInterval
+	if spawnInterval <= 0 {
+		spawnInterval = types.InitialSpawnInterval
+	}
+	return LevelData{
+		Grid:          grid,
+		Junctions:     ParseGrid(grid),
+		SpawnInterval: spawnInterval,
+		Goal:          fmt.Sprintf("Generated network %d! Spell '%s' - watch out for decoy letters!", level, word),
+		TargetWord:    word,
+	}, nil
+}
+
+// generateGrid draws the network with one branch ending at each of labels,
+// aiming for d.Junctions junctions. word is what the grid is validated
+// against, so every letter in it must be among labels.
+func generateGrid(rng *rand.Rand, d Difficulty, labels []rune, title, word string) ([]string, error) {
+	cols := (d.Width-3-1)/latticeX + 1
+	rows := (d.Height-3-headerRows)/latticeY + 1
+	if cols < 3 || rows < 2 {
+		return nil, fmt.Errorf("a %dx%d grid is too small for a network", d.Width, d.Height)
+	}
+	branching := min(max(d.Branching, 2), 3)
+	leaves := len(labels)
+
+	// Every junction adds between 1 and branching-1 routes to the single
+	// route leaving the spawn.
+	minJunctions := (leaves - 1 + branching - 2) / (branching - 1)
+	target := min(max(d.Junctions, minJunctions), leaves-1)
+
+	var best []string
+	bestScore := -1
+	for attempt := 0; attempt < generatorAttempts && bestScore != 0; attempt++ {
+		tree := growTree(rng, cols, rows, branching)
+		ends := pickEnds(rng, tree, leaves, d.PathLength)
+		if ends == nil {
+			continue
+		}
+		used := usedNodes(tree, ends)
+
+		junctions := 0
+		for node := range used {
+			if countUsedChildren(tree, used, node) >= 2 {
+				junctions++
+			}
+		}
+		score := abs(junctions - target)
+		if bestScore >= 0 && score >= bestScore {
+			continue
+		}
+
+		rng.Shuffle(len(ends), func(i, j int) { ends[i], ends[j] = ends[j], ends[i] })
+		grid := drawNetwork(d.Width, d.Height, title, tree, used, ends, labels)
+		data := LevelData{Grid: grid, Junctions: ParseGrid(grid), TargetWord: word}
+		if HasErrors(Validate(data)) {
+			continue
+		}
+		best, bestScore = grid, score
+	}
+
+	if best == nil {
+		return nil, fmt.Errorf("no network with %d letters at least %d segments from the spawn fits a %dx%d grid",
+			leaves, d.PathLength, d.Width, d.Height)
+	}
+	return best, nil
+}
+
+// pickWord picks a word with about destinations different letters, one
+// either way so the same spec doesn't always spell the same word.
+func pickWord(rng *rand.Rand, destinations int) string {
+	var candidates []string
+	for slack := 1; len(candidates) == 0; slack++ {
+		for _, word := range generatorWords {
+			if abs(len(uniqueLetters(word))-destinations) <= slack {
+				candidates = append(candidates, word)
+			}
+		}
+	}
+	return candidates[rng.Intn(len(candidates))]
+}
+
+func uniqueLetters(word string) []rune {
+	var letters []rune
+	for _, letter := range word {
+		if !strings.ContainsRune(string(letters), letter) {
+			letters = append(letters, letter)
+		}
+	}
+	return letters
+}
+
+// pickDecoys never uses S, which next to a wall would read as a spawn.
+func pickDecoys(rng *rand.Rand, letters []rune, count int) []rune {
+	var pool []rune
+	for letter := 'A'; letter <= 'Z'; letter++ {
+		if letter != 'S' && !strings.ContainsRune(string(letters), letter) {
+			pool = append(pool, letter)
+		}
+	}
+	rng.Shuffle(len(pool), func(i, j int) { pool[i], pool[j] = pool[j], pool[i] })
+	return pool[:min(count, len(pool))]
+}
+
+// growTree spans the lattice with a random tree from the spawn, giving no
+// node more than branching children. The spawn itself only leads right.
+func growTree(rng *rand.Rand, cols, rows, branching int) networkTree {
+	root := latticeNode{0, 0}
+	first := latticeNode{1, 0}
+	tree := networkTree{
+		parent:   map[latticeNode]latticeNode{first: root},
+		children: map[latticeNode][]latticeNode{root: {first}},
+		depth:    map[latticeNode]int{root: 0, first: 1},
+	}
+
+	type edge struct{ from, to latticeNode }
+	var frontier []edge
+	addEdges := func(from latticeNode) {
+		for _, dir := range junctionDirections {
+			to := latticeNode{from.x + dir.X, from.y + dir.Y}
+			if to.x >= 1 && to.x < cols && to.y >= 0 && to.y < rows {
+				frontier = append(frontier, edge{from, to})
+			}
+		}
+	}
+	addEdges(first)
+
+	for len(frontier) > 0 {
+		i := rng.Intn(len(frontier))
+		e := frontier[i]
+		frontier[i] = frontier[len(frontier)-1]
+		frontier = frontier[:len(frontier)-1]
+
+		if _, visited := tree.depth[e.to]; visited || len(tree.children[e.from]) >= branching {
+			continue
+		}
+		tree.parent[e.to] = e.from
+		tree.children[e.from] = append(tree.children[e.from], e.to)
+		tree.depth[e.to] = tree.depth[e.from] + 1
+		addEdges(e.to)
+	}
+	return tree
+}
+
+// pickEnds chooses count leaves of the tree at least minDepth from the
+// spawn, or nil if there aren't enough.
+func pickEnds(rng *rand.Rand, tree networkTree, count, minDepth int) []latticeNode {
+	var leaves []latticeNode
+	for node, depth := range tree.depth {
+		if len(tree.children[node]) == 0 && depth >= minDepth {
+			leaves = append(leaves, node)
+		}
+	}
+	if len(leaves) < count {
+		return nil
+	}
+	// Map order is random; sort first so the seed alone decides the pick.
+	sort.Slice(leaves, func(i, j int) bool {
+		if leaves[i].y != leaves[j].y {
+			return leaves[i].y < leaves[j].y
+		}
+		return leaves[i].x < leaves[j].x
+	})
+	rng.Shuffle(len(leaves), func(i, j int) { leaves[i], leaves[j] = leaves[j], leaves[i] })
+	return leaves[:count]
+}
+
+// usedNodes is every node on the way from the spawn to one of the ends.
+func usedNodes(tree networkTree, ends []latticeNode) map[latticeNode]bool {
+	used := map[latticeNode]bool{{0, 0}: true}
+	for _, node := range ends {
+		for !used[node] {
+			used[node] = true
+			node = tree.parent[node]
+		}
+	}
+	return used
+}
+
+func countUsedChildren(tree networkTree, used map[latticeNode]bool, node latticeNode) int {
+	count := 0
+	for _, child := range tree.children[node] {
+		if used[child] {
+			count++
+		}
+	}
+	return count
+}
+
+func drawNetwork(width, height int, title string, tree networkTree, used map[latticeNode]bool, ends []latticeNode, letters []rune) []string {
+	rows := make([][]rune, height)
+	for y := range rows {
+		rows[y] = make([]rune, width)
+		for x := range rows[y] {
+			if y == 0 || y == height-1 || x == 0 || x == width-1 {
+				rows[y][x] = '#'
+			} else {
+				rows[y][x] = ' '
+			}
+		}
+	}
+	copy(rows[2][1:width-1], []rune(title))
+
+	cell := func(n latticeNode) (int, int) {
+		return 1 + n.x*latticeX, headerRows + n.y*latticeY
+	}
+
+	for node := range used {
+		x, y := cell(node)
+		parent, hasParent := tree.parent[node]
+		var children []latticeNode
+		for _, child := range tree.children[node] {
+			if used[child] {
+				children = append(children, child)
+			}
+		}
+
+		switch {
+		case !hasParent:
+			rows[y][x] = 'S'
+		case len(children) == 0:
+			// Letters are filled in below.
+		case len(children) == 1 && (children[0].x-node.x == node.x-parent.x) && (children[0].y-node.y == node.y-parent.y):
+			if node.y == parent.y {
+				rows[y][x] = '-'
+			} else {
+				rows[y][x] = '|'
+			}
+		default:
+			rows[y][x] = '+'
+		}
+
+		for _, child := range children {
+			cx, cy := cell(child)
+			for sx, sy := x+sign(cx-x), y+sign(cy-y); sx != cx || sy != cy; sx, sy = sx+sign(cx-x), sy+sign(cy-y) {
+				if sy == y {
+					rows[sy][sx] = '-'
+				} else {
+					rows[sy][sx] = '|'
+				}
+			}
+		}
+	}
+
+	for i, end := range ends {
+		x, y := cell(end)
+		rows[y][x] = letters[i]
+	}
+
+	grid := make([]string, height)
+	for y, row := range rows {
+		grid[y] = string(row)
+	}
+	return grid
+}
+
+func sign(n int) int {
+	switch {
+	case n > 0:
+		return 1
+	case n < 0:
+		return -1
+	}
+	return 0
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,52 @@
+package levels
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/maverickkamal/Packet-Rush/internal/types"
+)
+
+func TestGeneratedLevelsAreValid(t *testing.T) {
+	for level := types.MaxLevel + 1; level <= types.MaxLevel+30; level++ {
+		d := DifficultyForLevel(level)
+		data := GeneratedLevel(level)
+
+		if len(data.Grid) != d.Height || len(data.Grid[0]) != d.Width {
+			t.Errorf("level %d: grid is %dx%d, want %dx%d", level, len(data.Grid[0]), len(data.Grid), d.Width, d.Height)
+		}
+		// Every branch ends at a letter, so not even a trap is allowed.
+		for _, issue := range Validate(data) {
+			t.Errorf("level %d: %s", level, issue)
+		}
+	}
+}
+
+func TestGeneratedLevelIsTheSameEveryTime(t *testing.T) {
+	level := types.MaxLevel + 5
+	if !reflect.DeepEqual(GeneratedLevel(level).Grid, GeneratedLevel(level).Grid) {
+		t.Error("the same level number generated two different maps")
+	}
+}
+
+func TestGenerateAimsForTheJunctionCount(t *testing.T) {
+	d := DifficultyForLevel(types.MaxLevel + 8)
+	for seed := int64(1); seed <= 20; seed++ {
+		data, err := Generate(seed, d, 1)
+		if err != nil {
+			t.Fatalf("seed %d: %v", seed, err)
+		}
+		switchable := 0
+		for _, j := range data.Junctions {
+			if j.IsSwitchable() {
+				switchable++
+			}
+			if len(j.Directions) > d.Branching {
+				t.Errorf("seed %d: junction at (%d,%d) has %d routes, more than %d", seed, j.X, j.Y, len(j.Directions), d.Branching)
+			}
+		}
+		if switchable == 0 || switchable > d.Junctions {
+			t.Errorf("seed %d: %d switchable junctions, want 1-%d", seed, switchable, d.Junctions)
+		}
+	}
+}
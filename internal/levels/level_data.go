@@ -14,9 +14,40 @@ type LevelData struct {
 	SpawnInterval time.Duration
 	Goal          string
 	TargetWord    string
+
+	// Ordered levels only count letters delivered in spelling order.
+	Ordered bool
+
+	// Spawners, when nil, are detected from the S cells in the grid.
+	// SpawnTable, when nil, draws letters evenly from the word.
+	Spawners   []types.Spawner
+	SpawnTable *types.SpawnTable
+
+	// Collisions and LinkCapacity make packets get in each other's way;
+	// by default they pass through each other.
+	Collisions   types.CollisionRule
+	LinkCapacity int
+
+	// TTL limits how far a packet travels, counting moves or, with TTLHops,
+	// junctions, so looping levels can't keep packets circling forever.
+	TTL     int
+	TTLHops bool
+
+	// Queue and Par are set for puzzles only.
+	Queue []types.QueuedPacket
+	Par   int
 }
 
 func GetLevelData(level int) LevelData {
+	if file, exists := externalLevels[level]; exists {
+		if data, err := file.LevelData(); err == nil {
+			return data
+		}
+	}
+	return BuiltinLevelData(level)
+}
+
+func BuiltinLevelData(level int) LevelData {
 	switch level {
 	case 1:
 		return getLevelOne()
@@ -39,7 +70,7 @@ func GetLevelData(level int) LevelData {
 	case 10:
 		return getLevelTen()
 	default:
-		return getAdvancedLevel(level)
+		return GeneratedLevel(level)
 	}
 }
 
@@ -68,17 +99,9 @@ func getLevelOne() LevelData {
 		"################################################################################",
 	}
 
-	junctions := make(map[string]*types.Junction)
-	junctions["10,4"] = types.NewJunction(10, 4, []types.Position{types.Right, types.Down}, '1')
-	junctions["21,4"] = types.NewJunction(21, 4, []types.Position{types.Right, types.Down}, '2')
-	junctions["32,4"] = types.NewJunction(32, 4, []types.Position{types.Right, types.Down}, '3')
-	junctions["10,14"] = types.NewJunction(10, 14, []types.Position{types.Right, types.Up}, '4')
-	junctions["21,14"] = types.NewJunction(21, 14, []types.Position{types.Right, types.Down}, '5')
-	junctions["32,14"] = types.NewJunction(32, 14, []types.Position{types.Right, types.Down}, '6')
-
 	return LevelData{
 		Grid:          grid,
-		Junctions:     junctions,
+		Junctions:     ParseGrid(grid),
 		SpawnInterval: 4 * time.Second,
 		Goal:          "Route G packets to 'G' and O packets to 'O' to spell 'GO'!",
 		TargetWord:    "GO",
@@ -110,18 +133,9 @@ func getLevelTwo() LevelData {
 		"################################################################################",
 	}
 
-	junctions := make(map[string]*types.Junction)
-	junctions["7,4"] = types.NewJunction(7, 4, []types.Position{types.Right, types.Down}, '1')
-	junctions["13,4"] = types.NewJunction(13, 4, []types.Position{types.Right, types.Down}, '2')
-	junctions["19,4"] = types.NewJunction(19, 4, []types.Position{types.Right, types.Down}, '3')
-	junctions["25,4"] = types.NewJunction(25, 4, []types.Position{types.Right, types.Down}, '4')
-	junctions["31,4"] = types.NewJunction(31, 4, []types.Position{types.Right, types.Down}, '5')
-	junctions["37,4"] = types.NewJunction(37, 4, []types.Position{types.Right, types.Down}, '6')
-	junctions["43,4"] = types.NewJunction(43, 4, []types.Position{types.Right, types.Down}, '7')
-
 	return LevelData{
 		Grid:          grid,
-		Junctions:     junctions,
+		Junctions:     ParseGrid(grid),
 		SpawnInterval: 3500 * time.Millisecond,
 		Goal:          "Route H packets to 'H' and I packets to 'I' to spell 'HI'!",
 		TargetWord:    "HI",
@@ -152,14 +166,9 @@ func getLevelThree() LevelData {
 		"################################################################################",
 	}
 
-	junctions := make(map[string]*types.Junction)
-	for i, x := range []int{4, 7, 10, 13, 16, 19, 22, 25, 28, 31, 34, 37, 40, 43, 46, 49, 52} {
-		junctions[fmt.Sprintf("%d,4", x)] = types.NewJunction(x, 4, []types.Position{types.Right, types.Down}, rune('1'+i))
-	}
-
 	return LevelData{
 		Grid:          grid,
-		Junctions:     junctions,
+		Junctions:     ParseGrid(grid),
 		SpawnInterval: 3 * time.Second,
 		Goal:          "Route W, I, N packets to spell 'WIN'! More junctions = more complexity!",
 		TargetWord:    "WIN",
@@ -167,9 +176,11 @@ func getLevelThree() LevelData {
 }
 
 func getLevelFour() LevelData {
+	grid := createStandardGrid("CODE")
+
 	return LevelData{
-		Grid:          createStandardGrid(4, "CODE"),
-		Junctions:     createStandardJunctions(6),
+		Grid:          grid,
+		Junctions:     ParseGrid(grid),
 		SpawnInterval: 2500 * time.Millisecond,
 		Goal:          "Multi-path routing! Spell 'CODE' with increasing complexity!",
 		TargetWord:    "CODE",
@@ -177,9 +188,11 @@ func getLevelFour() LevelData {
 }
 
 func getLevelFive() LevelData {
+	grid := createStandardGrid("RUSH")
+
 	return LevelData{
-		Grid:          createStandardGrid(5, "RUSH"),
-		Junctions:     createStandardJunctions(8),
+		Grid:          grid,
+		Junctions:     ParseGrid(grid),
 		SpawnInterval: 2 * time.Second,
 		Goal:          "Master level! Route R-U-S-H packets through the network maze!",
 		TargetWord:    "RUSH",
@@ -187,9 +200,11 @@ func getLevelFive() LevelData {
 }
 
 func getLevelSix() LevelData {
+	grid := createStandardGrid("EXPERT")
+
 	return LevelData{
-		Grid:          createStandardGrid(6, "EXPERT"),
-		Junctions:     createStandardJunctions(10),
+		Grid:          grid,
+		Junctions:     ParseGrid(grid),
 		SpawnInterval: 1800 * time.Millisecond,
 		Goal:          "Expert level! Spell 'EXPERT' with precision routing!",
 		TargetWord:    "EXPERT",
@@ -197,9 +212,11 @@ func getLevelSix() LevelData {
 }
 
 func getLevelSeven() LevelData {
+	grid := createStandardGrid("GENIUS")
+
 	return LevelData{
-		Grid:          createStandardGrid(7, "GENIUS"),
-		Junctions:     createStandardJunctions(12),
+		Grid:          grid,
+		Junctions:     ParseGrid(grid),
 		SpawnInterval: 1500 * time.Millisecond,
 		Goal:          "Genius level! Route 'GENIUS' packets through complex paths!",
 		TargetWord:    "GENIUS",
@@ -207,9 +224,11 @@ func getLevelSeven() LevelData {
 }
 
 func getLevelEight() LevelData {
+	grid := createStandardGrid("MASTER")
+
 	return LevelData{
-		Grid:          createStandardGrid(8, "MASTER"),
-		Junctions:     createStandardJunctions(14),
+		Grid:          grid,
+		Junctions:     ParseGrid(grid),
 		SpawnInterval: 1300 * time.Millisecond,
 		Goal:          "Master level! Spell 'MASTER' with network mastery!",
 		TargetWord:    "MASTER",
@@ -217,9 +236,11 @@ func getLevelEight() LevelData {
 }
 
 func getLevelNine() LevelData {
+	grid := createStandardGrid("LEGEND")
+
 	return LevelData{
-		Grid:          createStandardGrid(9, "LEGEND"),
-		Junctions:     createStandardJunctions(16),
+		Grid:          grid,
+		Junctions:     ParseGrid(grid),
 		SpawnInterval: 1100 * time.Millisecond,
 		Goal:          "Legendary routing! Spell 'LEGEND' like a true network hero!",
 		TargetWord:    "LEGEND",
@@ -227,95 +248,78 @@ func getLevelNine() LevelData {
 }
 
 func getLevelTen() LevelData {
+	grid := createStandardGrid("CHAMPION")
+
 	return LevelData{
-		Grid:          createStandardGrid(10, "CHAMPION"),
-		Junctions:     createStandardJunctions(20),
+		Grid:          grid,
+		Junctions:     ParseGrid(grid),
 		SpawnInterval: 1 * time.Second,
 		Goal:          "FINAL LEVEL! Spell 'CHAMPION' - prove you're the ultimate router!",
 		TargetWord:    "CHAMPION",
 	}
 }
 
-func getAdvancedLevel(level int) LevelData {
-	words := []string{"ULTIMATE", "SUPREME", "INFINITE", "ETERNAL", "COSMIC"}
-	word := words[(level-11)%len(words)]
-
-	return LevelData{
-		Grid:          createStandardGrid(level, word),
-		Junctions:     createStandardJunctions(20 + (level-10)*2),
-		SpawnInterval: 800 * time.Millisecond,
-		Goal:          fmt.Sprintf("Advanced Level %d! Spell '%s' with ultimate skill!", level, word),
-		TargetWord:    word,
-	}
-}
-
-func createStandardGrid(level int, word string) []string {
+// createStandardGrid draws a trunk line from the spawn with one branch per
+// pair of distinct letters. Each branch drops to a second junction that
+// splits right to one letter and down to the other; an odd letter out sits
+// at the end of the trunk.
+func createStandardGrid(word string) []string {
 	grid := []string{
 		"################################################################################",
 		"#                                                                              #",
-		fmt.Sprintf("#  🚀 LEVEL %d: ADVANCED NETWORK - Spell '%s'!                            #", level, word),
+		fmt.Sprintf("#  🚀 ADVANCED NETWORK - Spell '%s'!                                      #", word),
 		"#                                                                              #",
 	}
 
-	wordRunes := []rune(word)
-	letterPositions := make(map[rune]string)
-
-	for i, letter := range wordRunes {
-		if i < 4 {
-			y := 4 + i*4
-			letterPositions[letter] = fmt.Sprintf("%d,%d", 70, y)
-		} else {
-			x := 10 + (i-4)*10
-			letterPositions[letter] = fmt.Sprintf("%d,%d", x, 16)
+	var letters []byte
+	for _, letter := range []byte(word) {
+		if !strings.ContainsRune(string(letters), rune(letter)) {
+			letters = append(letters, letter)
 		}
 	}
 
-	for i := 0; i < 16; i++ {
-		line := "#S"
-		for j := 0; j < 35; j++ {
-			if j%3 == 0 {
-				line += "-+"
-			} else {
-				line += "--"
-			}
-		}
-	
-		line += strings.Repeat("-", 5)
-		if i == 4 && len(wordRunes) > 0 {
-			line += string(wordRunes[0])
-		} else if i == 8 && len(wordRunes) > 1 {
-			line += string(wordRunes[1])
-		} else if i == 12 && len(wordRunes) > 2 {
-			line += string(wordRunes[2])
-		} else if i == 16 && len(wordRunes) > 3 {
-			line += string(wordRunes[3])
-		} else {
-			line += " "
+	rows := make([][]byte, 16)
+	for i := range rows {
+		rows[i] = []byte("#" + strings.Repeat(" ", 78) + "#")
+	}
+	trunk := rows[0]
+	trunk[1] = 'S'
+
+	columns := len(letters) / 2
+	end := 2
+	for c := 0; c < columns; c++ {
+		x := 10 + c*10
+		for end < x {
+			trunk[end] = '-'
+			end++
 		}
+		trunk[x] = '+'
+		end = x + 1
 
-		line += strings.Repeat(" ", 80-len(line)-1) + "#"
-		grid = append(grid, line)
+		for y := 1; y < 8; y++ {
+			rows[y][x] = '|'
+		}
+		rows[8][x] = '+'
+		rows[8][x+1], rows[8][x+2], rows[8][x+3] = '-', '-', '-'
+		rows[8][x+4] = letters[2*c]
+		for y := 9; y < 14; y++ {
+			rows[y][x] = '|'
+		}
+		rows[14][x] = letters[2*c+1]
 	}
 
-	grid = append(grid, "################################################################################")
-	return grid
-}
-
-func createStandardJunctions(count int) map[string]*types.Junction {
-	junctions := make(map[string]*types.Junction)
-
-	for i := 0; i < count; i++ {
-		x := 3 + i*4
-		y := 4 + (i%4)*3
-		if x < 70 {
-			directions := []types.Position{types.Right, types.Down}
-			if i%3 == 0 {
-				directions = append(directions, types.Up)
-			}
-			junctions[fmt.Sprintf("%d,%d", x, y)] = types.NewJunction(x, y, directions, rune('1'+i%9))
+	if len(letters)%2 == 1 {
+		for end < 70 {
+			trunk[end] = '-'
+			end++
 		}
+		trunk[70] = letters[len(letters)-1]
 	}
 
-	return junctions
-}
+	for _, row := range rows {
+		grid = append(grid, string(row))
+	}
 
+	grid = append(grid, "################################################################################")
+	return grid
+}
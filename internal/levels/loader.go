@@ -0,0 +1,364 @@
+package levels
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/maverickkamal/Packet-Rush/internal/types"
+)
+
+// LevelFile is the on-disk JSON representation of a level.
+type LevelFile struct {
+	Path string `json:"-"`
+
+	Level         int             `json:"level"`
+	Grid          []string        `json:"grid"`
+	Junctions     []JunctionFile  `json:"junctions,omitempty"`
+	SpawnInterval string          `json:"spawn_interval"`
+	Goal          string          `json:"goal"`
+	TargetWord    string          `json:"target_word"`
+	Ordered       bool            `json:"ordered,omitempty"`
+	Spawners      []SpawnerFile   `json:"spawners,omitempty"`
+	SpawnTable    *SpawnTableFile `json:"spawn_table,omitempty"`
+	Collisions    string          `json:"collisions,omitempty"`
+	LinkCapacity  int             `json:"link_capacity,omitempty"`
+	TTL           int             `json:"ttl,omitempty"`
+	TTLHops       bool            `json:"ttl_hops,omitempty"`
+}
+
+// SpawnTableFile weights the letters the level's spawners draw from. Letters
+// default to the word's, weighted by how often it uses them; decoys must not
+// be in the word and are routed to a '*' sink.
+type SpawnTableFile struct {
+	Letters map[string]int `json:"letters,omitempty"`
+	Decoys  map[string]int `json:"decoys,omitempty"`
+	Window  int            `json:"window,omitempty"`
+}
+
+// SpawnerFile declares an S packets enter from. Everything but the position
+// is optional: the direction defaults to the track the S feeds, the interval
+// to the level's, and the letters to the level's spawn table.
+type SpawnerFile struct {
+	X         int    `json:"x"`
+	Y         int    `json:"y"`
+	Direction string `json:"direction,omitempty"`
+	Interval  string `json:"interval,omitempty"`
+	Letters   string `json:"letters,omitempty"`
+	Delay     string `json:"delay,omitempty"`
+}
+
+type JunctionFile struct {
+	X          int      `json:"x"`
+	Y          int      `json:"y"`
+	ID         string   `json:"id,omitempty"`
+	Directions []string `json:"directions"`
+}
+
+var externalLevels = make(map[int]LevelFile)
+
+func LoadLevelFile(path string) (LevelFile, error) {
+	file, err := ReadLevelFile(path)
+	if err != nil {
+		return file, err
+	}
+	if _, err := file.LevelData(); err != nil {
+		return file, fmt.Errorf("%s: %w", path, err)
+	}
+	return file, nil
+}
+
+// ReadLevelFile parses the file without checking it describes a playable
+// level, so half-finished levels can still be opened in the editor.
+func ReadLevelFile(path string) (LevelFile, error) {
+	var file LevelFile
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return file, err
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return file, fmt.Errorf("%s: %w", path, err)
+	}
+	file.Path = path
+	return file, nil
+}
+
+func (f LevelFile) Save(path string) error {
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+	}
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// LoadLevelDir registers every *.json level in dir so GetLevelData prefers
+// it over the built-in level with the same number.
+func LoadLevelDir(dir string) error {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return err
+	}
+	sort.Strings(paths)
+
+	loaded := make(map[int]string)
+	for _, path := range paths {
+		file, err := LoadLevelFile(path)
+		if err != nil {
+			return err
+		}
+		if other, exists := loaded[file.Level]; exists {
+			return fmt.Errorf("%s: level %d already defined in %s", path, file.Level, other)
+		}
+		loaded[file.Level] = path
+		externalLevels[file.Level] = file
+	}
+	return nil
+}
+
+func ExternalLevels() []LevelFile {
+	files := make([]LevelFile, 0, len(externalLevels))
+	for _, file := range externalLevels {
+		files = append(files, file)
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Level < files[j].Level })
+	return files
+}
+
+// LevelData builds fresh level state from the file, so every call hands out
+// its own junctions.
+func (f LevelFile) LevelData() (LevelData, error) {
+	if f.Level < 1 {
+		return LevelData{}, fmt.Errorf("level number must be at least 1, got %d", f.Level)
+	}
+	if len(f.Grid) == 0 {
+		return LevelData{}, fmt.Errorf("level %d has an empty grid", f.Level)
+	}
+	if f.TargetWord == "" {
+		return LevelData{}, fmt.Errorf("level %d has no target word", f.Level)
+	}
+
+	spawnInterval := types.InitialSpawnInterval
+	if f.SpawnInterval != "" {
+		d, err := time.ParseDuration(f.SpawnInterval)
+		if err != nil {
+			return LevelData{}, fmt.Errorf("level %d: bad spawn_interval: %w", f.Level, err)
+		}
+		if d <= 0 {
+			return LevelData{}, fmt.Errorf("level %d: spawn_interval must be positive", f.Level)
+		}
+		spawnInterval = d
+	}
+
+	collisions, ok := types.ParseCollisionRule(f.Collisions)
+	if !ok {
+		return LevelData{}, fmt.Errorf("level %d: unknown collisions %q, want \"crash\" or \"wait\"", f.Level, f.Collisions)
+	}
+	if f.LinkCapacity < 0 {
+		return LevelData{}, fmt.Errorf("level %d: link_capacity can't be negative", f.Level)
+	}
+	if f.TTL < 0 {
+		return LevelData{}, fmt.Errorf("level %d: ttl can't be negative", f.Level)
+	}
+	if f.TTLHops && f.TTL == 0 {
+		return LevelData{}, fmt.Errorf("level %d: ttl_hops needs a ttl to count hops against", f.Level)
+	}
+
+	spawners, err := f.spawners()
+	if err != nil {
+		return LevelData{}, err
+	}
+	table, err := f.spawnTable()
+	if err != nil {
+		return LevelData{}, err
+	}
+
+	if len(f.Junctions) == 0 {
+		return LevelData{
+			Grid:          f.Grid,
+			Junctions:     ParseGrid(f.Grid),
+			SpawnInterval: spawnInterval,
+			Goal:          f.Goal,
+			TargetWord:    f.TargetWord,
+			Ordered:       f.Ordered,
+			Spawners:      spawners,
+			SpawnTable:    table,
+			Collisions:    collisions,
+			LinkCapacity:  f.LinkCapacity,
+			TTL:           f.TTL,
+			TTLHops:       f.TTLHops,
+		}, nil
+	}
+
+	junctions := make(map[string]*types.Junction)
+	for _, j := range f.Junctions {
+		if j.Y < 0 || j.Y >= len(f.Grid) || j.X < 0 || j.X >= len(f.Grid[j.Y]) {
+			return LevelData{}, fmt.Errorf("level %d: junction %q at (%d,%d) is outside the grid", f.Level, j.ID, j.X, j.Y)
+		}
+		// Junctions without an id can only be switched with the mouse or
+		// cursor; a single-direction one is just a fixed corner.
+		var id rune
+		if j.ID != "" {
+			r, size := utf8.DecodeRuneInString(j.ID)
+			if size != len(j.ID) || !types.IsJunctionKey(r) {
+				return LevelData{}, fmt.Errorf("level %d: junction at (%d,%d) needs an id from 1 to 9", f.Level, j.X, j.Y)
+			}
+			id = r
+		}
+		if len(j.Directions) == 0 {
+			return LevelData{}, fmt.Errorf("level %d: junction %q has no directions", f.Level, j.ID)
+		}
+
+		directions := make([]types.Position, 0, len(j.Directions))
+		for _, name := range j.Directions {
+			dir, ok := types.ParseDirection(name)
+			if !ok {
+				return LevelData{}, fmt.Errorf("level %d: junction %q has unknown direction %q", f.Level, j.ID, name)
+			}
+			directions = append(directions, dir)
+		}
+
+		key := fmt.Sprintf("%d,%d", j.X, j.Y)
+		if _, exists := junctions[key]; exists {
+			return LevelData{}, fmt.Errorf("level %d: two junctions at (%d,%d)", f.Level, j.X, j.Y)
+		}
+		junctions[key] = types.NewJunction(j.X, j.Y, directions, id)
+	}
+
+	return LevelData{
+		Grid:          f.Grid,
+		Junctions:     junctions,
+		SpawnInterval: spawnInterval,
+		Goal:          f.Goal,
+		TargetWord:    f.TargetWord,
+		Ordered:       f.Ordered,
+		Spawners:      spawners,
+		SpawnTable:    table,
+		Collisions:    collisions,
+		LinkCapacity:  f.LinkCapacity,
+		TTL:           f.TTL,
+		TTLHops:       f.TTLHops,
+	}, nil
+}
+
+func (f LevelFile) spawnTable() (*types.SpawnTable, error) {
+	t := f.SpawnTable
+	if t == nil {
+		return nil, nil
+	}
+
+	table := &types.SpawnTable{Window: t.Window}
+	if t.Window < 0 {
+		return nil, fmt.Errorf("level %d: spawn_table window can't be negative", f.Level)
+	}
+	if distinct := len(uniqueLetters(f.TargetWord)); t.Window > 0 && t.Window < distinct {
+		return nil, fmt.Errorf("level %d: spawn_table window %d is too small for the %d different letters of %q", f.Level, t.Window, distinct, f.TargetWord)
+	}
+
+	if len(t.Letters) == 0 {
+		for _, letter := range uniqueLetters(f.TargetWord) {
+			weight := strings.Count(f.TargetWord, string(letter))
+			table.Letters = append(table.Letters, types.LetterWeight{Letter: letter, Weight: weight})
+		}
+	}
+	letters, err := letterWeights(f.Level, "letters", t.Letters)
+	if err != nil {
+		return nil, err
+	}
+	table.Letters = append(table.Letters, letters...)
+	for _, w := range letters {
+		if !strings.ContainsRune(f.TargetWord, w.Letter) {
+			return nil, fmt.Errorf("level %d: spawn_table letter %c is not in %q; list it under decoys", f.Level, w.Letter, f.TargetWord)
+		}
+	}
+
+	table.Decoys, err = letterWeights(f.Level, "decoys", t.Decoys)
+	if err != nil {
+		return nil, err
+	}
+	for _, w := range table.Decoys {
+		if strings.ContainsRune(f.TargetWord, w.Letter) {
+			return nil, fmt.Errorf("level %d: spawn_table decoy %c is in %q", f.Level, w.Letter, f.TargetWord)
+		}
+	}
+	return table, nil
+}
+
+// letterWeights turns a JSON weight map into a list in letter order, so the
+// same seed always draws the same letters.
+func letterWeights(level int, field string, weights map[string]int) ([]types.LetterWeight, error) {
+	list := make([]types.LetterWeight, 0, len(weights))
+	for key, weight := range weights {
+		letter, size := utf8.DecodeRuneInString(key)
+		if size != len(key) || !types.IsLetterDestination(letter) {
+			return nil, fmt.Errorf("level %d: spawn_table %s key %q must be a single letter", level, field, key)
+		}
+		if weight <= 0 {
+			return nil, fmt.Errorf("level %d: spawn_table %s weight for %c must be positive", level, field, letter)
+		}
+		list = append(list, types.LetterWeight{Letter: letter, Weight: weight})
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Letter < list[j].Letter })
+	return list, nil
+}
+
+// spawners returns the declared spawners, or nil to detect them from the
+// grid.
+func (f LevelFile) spawners() ([]types.Spawner, error) {
+	if len(f.Spawners) == 0 {
+		return nil, nil
+	}
+
+	spawners := make([]types.Spawner, 0, len(f.Spawners))
+	for _, sf := range f.Spawners {
+		if gridChar(f.Grid, sf.X, sf.Y) != 'S' {
+			return nil, fmt.Errorf("level %d: spawner at (%d,%d) is not on an 'S'", f.Level, sf.X, sf.Y)
+		}
+		sp := types.Spawner{X: sf.X, Y: sf.Y, Letters: sf.Letters}
+
+		if sf.Direction != "" {
+			dir, ok := types.ParseDirection(sf.Direction)
+			if !ok {
+				return nil, fmt.Errorf("level %d: spawner at (%d,%d) has unknown direction %q", f.Level, sf.X, sf.Y, sf.Direction)
+			}
+			sp.Dir = dir
+		} else if dir, ok := spawnDirection(f.Grid, sf.X, sf.Y); ok {
+			sp.Dir = dir
+		} else {
+			return nil, fmt.Errorf("level %d: spawner at (%d,%d) needs a direction", f.Level, sf.X, sf.Y)
+		}
+
+		if sf.Interval != "" {
+			d, err := time.ParseDuration(sf.Interval)
+			if err != nil {
+				return nil, fmt.Errorf("level %d: spawner at (%d,%d) has a bad interval: %w", f.Level, sf.X, sf.Y, err)
+			}
+			if d <= 0 {
+				return nil, fmt.Errorf("level %d: spawner at (%d,%d) needs a positive interval", f.Level, sf.X, sf.Y)
+			}
+			sp.Interval = d
+		}
+		if sf.Delay != "" {
+			d, err := time.ParseDuration(sf.Delay)
+			if err != nil {
+				return nil, fmt.Errorf("level %d: spawner at (%d,%d) has a bad delay: %w", f.Level, sf.X, sf.Y, err)
+			}
+			if d < 0 {
+				return nil, fmt.Errorf("level %d: spawner at (%d,%d) has a negative delay", f.Level, sf.X, sf.Y)
+			}
+			sp.Delay = d
+		}
+		spawners = append(spawners, sp)
+	}
+	return spawners, nil
+}
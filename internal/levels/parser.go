@@ -0,0 +1,181 @@
+package levels
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/maverickkamal/Packet-Rush/internal/types"
+)
+
+// junctionDirections is the order in which detected routes are offered, so
+// the first (initially active) route favours the main line to the right.
+var junctionDirections = []types.Position{types.Right, types.Down, types.Left, types.Up}
+
+func gridChar(grid []string, x, y int) byte {
+	if y < 0 || y >= len(grid) || x < 0 || x >= len(grid[y]) {
+		return '#'
+	}
+	return grid[y][x]
+}
+
+func isTrackFor(c byte, dir types.Position) bool {
+	if dir.X != 0 {
+		return c == '-'
+	}
+	return c == '|'
+}
+
+func isPort(c byte) bool {
+	return (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') || c == types.Sink
+}
+
+func connects(grid []string, x, y int, dir types.Position) bool {
+	c := gridChar(grid, x+dir.X, y+dir.Y)
+	return c == '+' || isPort(c) || isTrackFor(c, dir)
+}
+
+// followTrack walks from (x, y) along dir over straight track and returns the
+// cell where the track ends: a junction, a port, or whatever interrupts it.
+func followTrack(grid []string, x, y int, dir types.Position) (types.Position, int) {
+	steps := 0
+	for {
+		x, y = x+dir.X, y+dir.Y
+		steps++
+		if !isTrackFor(gridChar(grid, x, y), dir) {
+			return types.Position{X: x, Y: y}, steps
+		}
+	}
+}
+
+// spawnDirection reports the direction an 'S' at (x, y) feeds track in from a
+// wall, which tells spawns apart from 'S' letter destinations at the end of a
+// line.
+func spawnDirection(grid []string, x, y int) (types.Position, bool) {
+	if gridChar(grid, x, y) != 'S' {
+		return types.Position{}, false
+	}
+	for _, dir := range junctionDirections {
+		c := gridChar(grid, x+dir.X, y+dir.Y)
+		if (c == '+' || isTrackFor(c, dir)) && gridChar(grid, x-dir.X, y-dir.Y) == '#' {
+			return dir, true
+		}
+	}
+	return types.Position{}, false
+}
+
+func FindSpawns(grid []string) []types.Position {
+	var spawns []types.Position
+	for y, row := range grid {
+		for x := 0; x < len(row); x++ {
+			if _, ok := spawnDirection(grid, x, y); ok {
+				spawns = append(spawns, types.Position{X: x, Y: y})
+			}
+		}
+	}
+	return spawns
+}
+
+// DetectSpawners makes a spawner for every S that feeds track in from a
+// wall, heading along that track at the level's spawn interval.
+func DetectSpawners(grid []string) []types.Spawner {
+	var spawners []types.Spawner
+	for _, spawn := range FindSpawns(grid) {
+		dir, _ := spawnDirection(grid, spawn.X, spawn.Y)
+		spawners = append(spawners, types.Spawner{X: spawn.X, Y: spawn.Y, Dir: dir})
+	}
+	return spawners
+}
+
+// trackDistances returns the shortest track distance from any spawn to every
+// junction reachable over drawn track.
+func trackDistances(grid []string) map[types.Position]int {
+	dist := make(map[types.Position]int)
+	done := make(map[types.Position]bool)
+	for _, spawn := range FindSpawns(grid) {
+		dist[spawn] = 0
+	}
+
+	for {
+		current, best := types.Position{}, math.MaxInt
+		for point, d := range dist {
+			if !done[point] && (d < best || d == best && (point.Y < current.Y || point.Y == current.Y && point.X < current.X)) {
+				current, best = point, d
+			}
+		}
+		if best == math.MaxInt {
+			return dist
+		}
+		done[current] = true
+
+		for _, dir := range junctionDirections {
+			if !connects(grid, current.X, current.Y, dir) {
+				continue
+			}
+			end, steps := followTrack(grid, current.X, current.Y, dir)
+			if gridChar(grid, end.X, end.Y) != '+' {
+				continue
+			}
+			if d, seen := dist[end]; !seen || best+steps < d {
+				dist[end] = best + steps
+			}
+		}
+	}
+}
+
+// ParseGrid detects every '+' in the grid and builds its junction. A route is
+// offered in each direction that has track leading away from the spawn;
+// track leading back towards the spawn is treated as the way in. Where lines
+// from two spawns meet at the same distance, a junction left with no way out
+// sends packets on along the line it shares with the other. Junctions with
+// more than one route get keys in reading order, fixed corners get none.
+func ParseGrid(grid []string) map[string]*types.Junction {
+	dist := trackDistances(grid)
+	junctions := make(map[string]*types.Junction)
+	keyIndex := 0
+
+	for y, row := range grid {
+		for x := 0; x < len(row); x++ {
+			if row[x] != '+' {
+				continue
+			}
+
+			here, reachable := dist[types.Position{X: x, Y: y}]
+			var directions, upstream, merges []types.Position
+			for _, dir := range junctionDirections {
+				if !connects(grid, x, y, dir) {
+					continue
+				}
+				if reachable {
+					end, _ := followTrack(grid, x, y, dir)
+					if there, isNode := dist[end]; isNode && there <= here {
+						if there == here && gridChar(grid, end.X, end.Y) == '+' {
+							merges = append(merges, dir)
+						}
+						upstream = append(upstream, dir)
+						continue
+					}
+				}
+				directions = append(directions, dir)
+			}
+			if len(directions) == 0 {
+				directions = merges
+			}
+
+			// A '+' that only carries traffic straight through is drawn
+			// track, not a junction.
+			if len(directions) == 1 && len(upstream) == 1 &&
+				directions[0] == (types.Position{X: -upstream[0].X, Y: -upstream[0].Y}) {
+				continue
+			}
+
+			junction := types.NewJunction(x, y, directions, 0)
+			if junction.IsSwitchable() {
+				junction.ID = types.JunctionKey(keyIndex)
+				keyIndex++
+			}
+			junctions[fmt.Sprintf("%d,%d", x, y)] = junction
+		}
+	}
+
+	return junctions
+}
@@ -0,0 +1,59 @@
+package levels
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/maverickkamal/Packet-Rush/internal/types"
+)
+
+func TestTracePathFollowsTheJunctions(t *testing.T) {
+	sim := forkSimulation("AB", false)
+	packet := &types.Packet{X: 1, Y: 1, PacketType: 'B', DirX: 1}
+
+	path, delivered := sim.TracePath(packet)
+	if delivered || path[len(path)-1] != (types.Position{X: 8, Y: 1}) {
+		t.Errorf("B traced to %v, delivered %v; want it to end wrongly at A", path[len(path)-1], delivered)
+	}
+
+	sim.Apply(types.SwitchInput(sim.Junctions["4,1"]))
+	path, delivered = sim.TracePath(packet)
+	want := []types.Position{{X: 2, Y: 1}, {X: 3, Y: 1}, {X: 4, Y: 1}, {X: 4, Y: 2}, {X: 4, Y: 3}}
+	if !delivered || !reflect.DeepEqual(path, want) {
+		t.Errorf("after the switch B traced %v, delivered %v; want %v delivered", path, delivered, want)
+	}
+}
+
+func TestTracePathStopsGoingRoundALoop(t *testing.T) {
+	grid := []string{
+		"#######",
+		"#+---+#",
+		"#|   |#",
+		"#+---+#",
+		"#######",
+	}
+	sim := trafficSimulation(grid, types.CollisionsOff, 0)
+	sim.Junctions = map[string]*types.Junction{
+		"1,1": types.NewJunction(1, 1, []types.Position{types.Right}, 0),
+		"5,1": types.NewJunction(5, 1, []types.Position{types.Down}, 0),
+		"5,3": types.NewJunction(5, 3, []types.Position{types.Left}, 0),
+		"1,3": types.NewJunction(1, 3, []types.Position{types.Up}, 0),
+	}
+	path, delivered := sim.TracePath(&types.Packet{X: 2, Y: 1, PacketType: 'A', DirX: 1})
+	if delivered || len(path) > 12 {
+		t.Errorf("a loop traced %d cells, delivered %v", len(path), delivered)
+	}
+}
+
+func TestPreviewColorsTheTrace(t *testing.T) {
+	m := forkModel('A')
+	trace := types.ColorGreen + types.ColorBright + "-"
+	if strings.Contains(m.View(), trace) {
+		t.Fatal("the trace shows before the preview is on")
+	}
+	press(m, "v")
+	if !strings.Contains(m.View(), trace) {
+		t.Error("the preview didn't trace the A to its port in green")
+	}
+}
@@ -0,0 +1,160 @@
+package levels
+
+import (
+	"fmt"
+
+	"github.com/maverickkamal/Packet-Rush/internal/types"
+)
+
+// puzzle is a hand-made puzzle level. Its junctions are all set before it
+// runs, so each spawner's packets go to one letter, and par is the fewest
+// switches that setup takes.
+type puzzle struct {
+	grid  []string
+	word  string
+	queue []types.QueuedPacket
+	par   int
+	goal  string
+}
+
+var puzzles = []puzzle{
+	{
+		grid: []string{
+			"################################################################################",
+			"#                                                                              #",
+			"#  PUZZLE 1: FIRST SWITCH - Spell 'GO'                                         #",
+			"#                                                                              #",
+			"#S------------------G                                                          #",
+			"#                                                                              #",
+			"#S---------+--------G                                                          #",
+			"#          |                                                                   #",
+			"#          |                                                                   #",
+			"#          O                                                                   #",
+			"################################################################################",
+		},
+		word:  "GO",
+		queue: []types.QueuedPacket{{Letter: 'G', Tick: 1}, {Letter: 'O', Tick: 1, Spawner: 1}},
+		par:   1,
+		goal:  "Set the junction for the O before you run. Once it runs, switches are locked!",
+	},
+	{
+		grid: []string{
+			"################################################################################",
+			"#                                                                              #",
+			"#  PUZZLE 2: TWO IN A ROW - Spell 'TOO'                                        #",
+			"#                                                                              #",
+			"#S------------------T                                                          #",
+			"#                                                                              #",
+			"#S-----+------------T                                                          #",
+			"#      |                                                                       #",
+			"#      +------------P                                                          #",
+			"#      |                                                                       #",
+			"#      O                                                                       #",
+			"#                                                                              #",
+			"################################################################################",
+		},
+		word:  "TOO",
+		queue: []types.QueuedPacket{{Letter: 'T', Tick: 1}, {Letter: 'O', Tick: 1, Spawner: 1}, {Letter: 'O', Tick: 5, Spawner: 1}},
+		par:   2,
+		goal:  "Two junctions in a row stand between the O and its port. Set both before you run.",
+	},
+	{
+		grid: []string{
+			"################################################################################",
+			"#                                                                              #",
+			"#  PUZZLE 3: THERE AND BACK - Spell 'NOON'                                     #",
+			"#                                                                              #",
+			"#S-----------------+-------O                                                   #",
+			"#                  |                                                           #",
+			"#      N-----------+                                                           #",
+			"#                                                                              #",
+			"#S-----------------+-------N                                                   #",
+			"#                  |                                                           #",
+			"#      O-----------+                                                           #",
+			"#                                                                              #",
+			"################################################################################",
+		},
+		word:  "NOON",
+		queue: []types.QueuedPacket{{Letter: 'N', Tick: 1}, {Letter: 'O', Tick: 1, Spawner: 1}, {Letter: 'O', Tick: 5, Spawner: 1}, {Letter: 'N', Tick: 5}},
+		par:   2,
+		goal:  "There and back: each line starts out pointing at the other's letter.",
+	},
+	{
+		grid: []string{
+			"################################################################################",
+			"#                                                                              #",
+			"#  PUZZLE 4: THREE WAYS - Spell 'BIT'                                          #",
+			"#          B                                                                   #",
+			"#S---------+---------I                                                         #",
+			"#          |                                                                   #",
+			"#          T                                                                   #",
+			"#                   T                                                          #",
+			"#S------------------+---------B                                                #",
+			"#                   |                                                          #",
+			"#                   I                                                          #",
+			"#                            T                                                 #",
+			"#S---------------------------+---------I                                       #",
+			"#                            |                                                 #",
+			"#                            B                                                 #",
+			"################################################################################",
+		},
+		word:  "BIT",
+		queue: []types.QueuedPacket{{Letter: 'B', Tick: 1}, {Letter: 'I', Tick: 1, Spawner: 1}, {Letter: 'T', Tick: 1, Spawner: 2}},
+		par:   5,
+		goal:  "A three-way junction cycles right, down, up. Count your presses!",
+	},
+	{
+		grid: []string{
+			"################################################################################",
+			"#                                       S                                      #",
+			"#  PUZZLE 5: THE TREE - Spell 'TREE'    |                                      #",
+			"#                                       |                                      #",
+			"#                                       |                                      #",
+			"#                                       |                                      #",
+			"#S-+------------------------------------+----------E                           #",
+			"#  |                                    |                                      #",
+			"#  +-----------------R                  R                                      #",
+			"#  |                                                                           #",
+			"#  T                                                                           #",
+			"#                                                                              #",
+			"#S------------------+----------R                                               #",
+			"#                   |                                                          #",
+			"#                   T                                                          #",
+			"################################################################################",
+		},
+		word:  "TREE",
+		queue: []types.QueuedPacket{{Letter: 'E', Tick: 1}, {Letter: 'R', Tick: 1, Spawner: 1}, {Letter: 'T', Tick: 1, Spawner: 2}, {Letter: 'E', Tick: 5}},
+		par:   2,
+		goal:  "Two lines share a junction and only one can have it. Find the other a way around.",
+	},
+}
+
+// PuzzleCount is how many puzzles there are.
+func PuzzleCount() int {
+	return len(puzzles)
+}
+
+// PuzzleLevelData is puzzle number n, counting from 1.
+func PuzzleLevelData(n int) LevelData {
+	p := puzzles[n-1]
+
+	return LevelData{
+		Grid:       p.grid,
+		Junctions:  ParseGrid(p.grid),
+		Goal:       fmt.Sprintf("Puzzle %d: %s", n, p.goal),
+		TargetWord: p.word,
+		Queue:      p.queue,
+		Par:        p.par,
+	}
+}
+
+// NewPuzzleSimulation starts puzzle number n, stopped so the junctions can
+// be set before anything moves. Numbers past the last puzzle wrap around to
+// the first.
+func NewPuzzleSimulation(n int, seed int64) *types.Simulation {
+	n = (n-1)%len(puzzles) + 1
+	data := PuzzleLevelData(n)
+	sim := NewSimulation(data, n, seed)
+	sim.StartPuzzle(data.Queue, data.Par)
+	return sim
+}
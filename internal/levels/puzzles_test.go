@@ -0,0 +1,80 @@
+package levels
+
+import (
+	"testing"
+
+	"github.com/maverickkamal/Packet-Rush/internal/types"
+)
+
+// solvePuzzle sets up puzzle n with presses[i] switches of its i-th
+// switchable junction, runs it and reports whether the word was spelled.
+func solvePuzzle(n int, presses []int) bool {
+	sim := NewPuzzleSimulation(n, 1)
+	for i, j := range sim.SwitchableJunctions() {
+		for k := 0; k < presses[i]; k++ {
+			sim.Apply(types.SwitchInput(j))
+		}
+	}
+	sim.Apply(types.Input{Kind: types.InputPause})
+	for tick := 0; tick < 500 && !sim.Finished(); tick++ {
+		sim.Step(nil)
+	}
+	return sim.LevelComplete
+}
+
+func TestPuzzlesSolveAtParFromOneSetup(t *testing.T) {
+	for n := 1; n <= PuzzleCount(); n++ {
+		if issues := Validate(PuzzleLevelData(n)); HasErrors(issues) {
+			t.Errorf("puzzle %d: %v", n, issues)
+		}
+
+		junctions := NewPuzzleSimulation(n, 1).SwitchableJunctions()
+		presses := make([]int, len(junctions))
+		fewest := -1
+		var try func(i, switches int)
+		try = func(i, switches int) {
+			if i == len(junctions) {
+				if (fewest < 0 || switches < fewest) && solvePuzzle(n, presses) {
+					fewest = switches
+				}
+				return
+			}
+			for presses[i] = 0; presses[i] < len(junctions[i].Directions); presses[i]++ {
+				try(i+1, switches+presses[i])
+			}
+		}
+		try(0, 0)
+
+		if par := PuzzleLevelData(n).Par; fewest != par {
+			t.Errorf("puzzle %d: fewest switches from one setup = %d, par = %d", n, fewest, par)
+		}
+	}
+}
+
+func TestRunningPuzzleTakesNoSwitches(t *testing.T) {
+	sim := NewPuzzleSimulation(1, 1)
+	junction := sim.SwitchableJunctions()[0]
+
+	sim.Step([]types.Input{{Kind: types.InputPause}})
+	sim.Apply(types.SwitchInput(junction))
+	if junction.ActiveDir != 0 || sim.Switches != 0 {
+		t.Error("a running puzzle took a switch")
+	}
+
+	// Stopping it again doesn't unlock the junctions.
+	sim.Apply(types.Input{Kind: types.InputPause})
+	sim.Apply(types.SwitchInput(junction))
+	if junction.ActiveDir != 0 || sim.Switches != 0 {
+		t.Error("a stopped puzzle took a switch after it had run")
+	}
+}
+
+func TestPuzzleStars(t *testing.T) {
+	for _, tc := range []struct{ switches, par, want int }{
+		{1, 2, 3}, {2, 2, 3}, {4, 2, 2}, {5, 2, 1},
+	} {
+		if got := types.PuzzleStars(tc.switches, tc.par); got != tc.want {
+			t.Errorf("PuzzleStars(%d, %d) = %d, want %d", tc.switches, tc.par, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package levels
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/maverickkamal/Packet-Rush/internal/types"
+)
+
+func forkModel(letter rune) *types.GameModel {
+	sim := NewSimulation(LevelData{Grid: forkGrid, Junctions: ParseGrid(forkGrid), TargetWord: "AB"}, 1, 1)
+	sim.Packets = append(sim.Packets, &types.Packet{X: 1, Y: 1, PacketType: letter, DirX: 1})
+	sim.Score = 100
+	return types.NewGameModel(sim)
+}
+
+func pressHint(m *types.GameModel) {
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'?'}})
+}
+
+func TestRouteHintNamesTheJunctionToSwitch(t *testing.T) {
+	m := forkModel('B')
+	route, ok := m.FindRoute(m.Packets[0])
+	if !ok {
+		t.Fatal("no route from S to B")
+	}
+	if len(route.Turns) != 1 || route.Turns[0].Dir != types.Down {
+		t.Fatalf("turns = %+v, want one turn down at the junction", route.Turns)
+	}
+
+	pressHint(m)
+	if m.Score != 100-types.HintPenalty {
+		t.Errorf("score after hint = %d, want %d", m.Score, 100-types.HintPenalty)
+	}
+	if view := m.View(); !strings.Contains(view, "switch red junctions 1") {
+		t.Errorf("view does not ask for junction 1 to be switched:\n%s", view)
+	}
+
+	pressHint(m)
+	if m.Score != 100-types.HintPenalty {
+		t.Errorf("hiding the hint charged again: score = %d", m.Score)
+	}
+}
+
+func TestRouteHintIsFreeWhenEveryPacketIsOnCourse(t *testing.T) {
+	m := forkModel('A')
+	pressHint(m)
+	if m.Score != 100 {
+		t.Errorf("score = %d, want no penalty for an unneeded hint", m.Score)
+	}
+	if view := m.View(); !strings.Contains(view, "no hint needed") {
+		t.Errorf("view does not say every packet is on course:\n%s", view)
+	}
+}
+
+func TestControlsFitTheBoardWidth(t *testing.T) {
+	for _, mode := range []types.Mode{types.ModeCampaign, types.ModePuzzle} {
+		m := forkModel('A')
+		m.Mode = mode
+		for _, line := range strings.Split(m.View(), "\n") {
+			if !strings.Contains(line, " Switch │ ") {
+				continue
+			}
+			if width := visibleWidth(line); width > 80 {
+				t.Errorf("mode %q: controls line is %d columns: %q", mode, width, line)
+			}
+		}
+	}
+}
+
+// visibleWidth counts the runes of line that aren't part of a color code.
+func visibleWidth(line string) int {
+	width, escaped := 0, false
+	for _, r := range line {
+		switch {
+		case r == '\x1b':
+			escaped = true
+		case escaped:
+			escaped = r != 'm'
+		default:
+			width++
+		}
+	}
+	return width
+}
@@ -0,0 +1,90 @@
+package levels
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/maverickkamal/Packet-Rush/internal/types"
+)
+
+// play runs level with seed for ticks, switching every junction in turn on
+// a fixed schedule.
+func play(level int, seed int64, ticks int) *types.Simulation {
+	sim := NewSimulation(GetLevelData(level), level, seed)
+	junctions := sim.SwitchableJunctions()
+	for tick := 0; tick < ticks && !sim.Finished(); tick++ {
+		var inputs []types.Input
+		if tick%7 == 0 && len(junctions) > 0 {
+			inputs = append(inputs, types.SwitchInput(junctions[tick/7%len(junctions)]))
+		}
+		sim.Step(inputs)
+	}
+	return sim
+}
+
+func TestSameSeedAndInputsPlayTheSameGame(t *testing.T) {
+	for _, level := range []int{1, 4, 12} {
+		a, b := play(level, 42, 300), play(level, 42, 300)
+		if !reflect.DeepEqual(a, b) {
+			t.Errorf("level %d: two runs with the same seed and inputs ended in different states", level)
+		}
+		if a.GameTime == 0 {
+			t.Errorf("level %d: the run never ticked", level)
+		}
+	}
+}
+
+func TestDifferentSeedsSpawnDifferently(t *testing.T) {
+	a, b := play(4, 1, 200), play(4, 2, 200)
+	if reflect.DeepEqual(a.Packets, b.Packets) && a.Score == b.Score && a.Misroutes == b.Misroutes {
+		t.Error("seeds 1 and 2 played exactly the same game")
+	}
+}
+
+func TestEverySpawnerSendsPackets(t *testing.T) {
+	data := LevelData{
+		Grid:          mergeGrid,
+		Junctions:     ParseGrid(mergeGrid),
+		SpawnInterval: GetLevelData(1).SpawnInterval,
+		TargetWord:    "AAAAAA",
+	}
+	if spawners := DetectSpawners(mergeGrid); len(spawners) != 2 {
+		t.Fatalf("detected %d spawners, want 2", len(spawners))
+	}
+
+	sim := NewSimulation(data, 1, 1)
+	spawnedAt := make(map[types.Position]int)
+	sim.OnEvent = func(e types.Event) {
+		if e.Kind == types.EventSpawn {
+			spawnedAt[types.Position{X: e.X, Y: e.Y}]++
+		}
+	}
+	for tick := 0; tick < 2000 && !sim.Finished(); tick++ {
+		sim.Step(nil)
+	}
+
+	for _, spawn := range []types.Position{{X: 1, Y: 1}, {X: 1, Y: 3}} {
+		if spawnedAt[spawn] == 0 {
+			t.Errorf("nothing spawned at %v", spawn)
+		}
+	}
+	if !sim.LevelComplete || sim.Misroutes != 0 {
+		t.Errorf("complete = %v with %d misroutes, want every packet delivered through the merge", sim.LevelComplete, sim.Misroutes)
+	}
+}
+
+func TestStoppedPacketIsLost(t *testing.T) {
+	junctions := ParseGrid(mergeGrid)
+	junctions["7,3"] = types.NewJunction(7, 3, nil, 0)
+	sim := NewSimulation(LevelData{Grid: mergeGrid, Junctions: junctions, TargetWord: "A"}, 1, 1)
+	sim.Spawners = nil
+	sim.Packets = append(sim.Packets, &types.Packet{X: 6, Y: 3, PacketType: 'A', DirX: 1})
+
+	sim.Step(nil)
+	if len(sim.Packets) != 0 {
+		t.Fatalf("the packet is still on the board at (%d,%d)", sim.Packets[0].X, sim.Packets[0].Y)
+	}
+	if sim.Misroutes != 1 || sim.Lives != types.LivesPerLevel-1 {
+		t.Errorf("misroutes = %d, lives = %d; want the packet lost", sim.Misroutes, sim.Lives)
+	}
+}
@@ -0,0 +1,65 @@
+package levels
+
+import (
+	"testing"
+
+	"github.com/maverickkamal/Packet-Rush/internal/types"
+)
+
+// sinkGrid has the sink where forkGrid has its B.
+var sinkGrid = []string{
+	"##########",
+	"#S--+---A#",
+	"#   |    #",
+	"#   *    #",
+	"##########",
+}
+
+func sinkSimulation() *types.Simulation {
+	data := LevelData{Grid: sinkGrid, Junctions: ParseGrid(sinkGrid), TargetWord: "AA"}
+	sim := NewSimulation(data, 1, 1)
+	sim.Spawners = nil
+	return sim
+}
+
+func TestDecoyScoresInTheSink(t *testing.T) {
+	sim := sinkSimulation()
+	sim.Packets = append(sim.Packets, &types.Packet{X: 4, Y: 2, PacketType: 'Z', DirY: 1, Decoy: true})
+	sim.Step(nil)
+	if sim.Score != types.DecoyPoints || len(sim.Packets) != 0 {
+		t.Errorf("score = %d with %d packets left, want the decoy taken for %d", sim.Score, len(sim.Packets), types.DecoyPoints)
+	}
+}
+
+func TestDecoyAtALetterCostsALife(t *testing.T) {
+	sim := sinkSimulation()
+	sim.Packets = append(sim.Packets, &types.Packet{X: 7, Y: 1, PacketType: 'Z', DirX: 1, Decoy: true})
+	sim.Step(nil)
+	if sim.Lives != types.LivesPerLevel-1 || len(sim.GoalProgress) != 0 {
+		t.Errorf("lives = %d, progress %q; want the decoy lost", sim.Lives, string(sim.GoalProgress))
+	}
+}
+
+func TestWordLetterInTheSinkIsDropped(t *testing.T) {
+	sim := sinkSimulation()
+	sim.Packets = append(sim.Packets, &types.Packet{X: 4, Y: 2, PacketType: 'A', DirY: 1})
+	sim.Step(nil)
+	if sim.Score != 0 || len(sim.Packets) != 0 {
+		t.Errorf("score = %d with %d packets left, want the letter dropped unscored", sim.Score, len(sim.Packets))
+	}
+}
+
+func TestValidateWantsASinkForDecoys(t *testing.T) {
+	table := &types.SpawnTable{
+		Letters: []types.LetterWeight{{Letter: 'A', Weight: 1}},
+		Decoys:  []types.LetterWeight{{Letter: 'Z', Weight: 1}},
+	}
+	withSink := LevelData{Grid: sinkGrid, Junctions: ParseGrid(sinkGrid), TargetWord: "A", SpawnTable: table}
+	if issues := Validate(withSink); HasErrors(issues) {
+		t.Errorf("level with a sink has errors: %v", issues)
+	}
+	withoutSink := LevelData{Grid: forkGrid, Junctions: ParseGrid(forkGrid), TargetWord: "A", SpawnTable: table}
+	if !HasErrors(Validate(withoutSink)) {
+		t.Error("decoys with no sink weren't reported")
+	}
+}
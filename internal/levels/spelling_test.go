@@ -0,0 +1,81 @@
+package levels
+
+import (
+	"testing"
+
+	"github.com/maverickkamal/Packet-Rush/internal/types"
+)
+
+// forkSimulation is forkGrid spelling word, with no spawners so only the
+// packets a test places move.
+func forkSimulation(word string, ordered bool) *types.Simulation {
+	data := LevelData{Grid: forkGrid, Junctions: ParseGrid(forkGrid), TargetWord: word, Ordered: ordered}
+	sim := NewSimulation(data, 1, 1)
+	sim.Spawners = nil
+	return sim
+}
+
+// deliver plays the tick that takes a packet of letter into its port on
+// forkGrid.
+func deliver(sim *types.Simulation, letter rune) {
+	packet := &types.Packet{X: 7, Y: 1, PacketType: letter, DirX: 1}
+	if letter == 'B' {
+		packet = &types.Packet{X: 4, Y: 2, PacketType: letter, DirY: 1}
+	}
+	sim.Packets = append(sim.Packets, packet)
+	sim.Step(nil)
+}
+
+func TestOrderedSpellingTurnsAwayLettersOutOfTurn(t *testing.T) {
+	sim := forkSimulation("AB", true)
+
+	deliver(sim, 'B')
+	if len(sim.GoalProgress) != 0 || sim.Score != 0 {
+		t.Errorf("B out of turn counted: progress %q, score %d", string(sim.GoalProgress), sim.Score)
+	}
+	if sim.Lives != types.LivesPerLevel || sim.Misroutes != 0 {
+		t.Errorf("B out of turn cost a life: lives %d, misroutes %d", sim.Lives, sim.Misroutes)
+	}
+	if len(sim.Packets) != 0 {
+		t.Error("B out of turn was left on the board")
+	}
+
+	deliver(sim, 'A')
+	deliver(sim, 'B')
+	if string(sim.GoalProgress) != "AB" || !sim.LevelComplete {
+		t.Errorf("progress %q, complete %v; want AB spelled", string(sim.GoalProgress), sim.LevelComplete)
+	}
+}
+
+func TestUnorderedSpellingTakesLettersInAnyOrder(t *testing.T) {
+	sim := forkSimulation("AB", false)
+	deliver(sim, 'B')
+	deliver(sim, 'A')
+	if !sim.LevelComplete {
+		t.Errorf("progress %q; want BA to spell AB", string(sim.GoalProgress))
+	}
+}
+
+func TestRepeatedLetterCountsOnlyAsOftenAsTheWordUsesIt(t *testing.T) {
+	sim := forkSimulation("ABA", false)
+	deliver(sim, 'B')
+	if sim.Needs('B') {
+		t.Error("a second B would count")
+	}
+	deliver(sim, 'A')
+	if !sim.Needs('A') {
+		t.Error("the second A wouldn't count")
+	}
+	deliver(sim, 'A')
+	if !sim.LevelComplete {
+		t.Errorf("progress %q; want ABA spelled", string(sim.GoalProgress))
+	}
+}
+
+func TestSpellInOrderAppliesToEveryLevel(t *testing.T) {
+	defer func() { spellInOrder = false }()
+	SpellInOrder()
+	if sim := NewSimulationForLevel(1, 1); !sim.Ordered {
+		t.Error("level 1 doesn't spell in order after SpellInOrder")
+	}
+}
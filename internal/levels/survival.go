@@ -0,0 +1,74 @@
+package levels
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/maverickkamal/Packet-Rush/internal/types"
+)
+
+// SurvivalWords are the words a survival run draws from. The survival map
+// has a letter for every letter they use, so new words should stick to the
+// same handful of letters.
+var SurvivalWords = []string{
+	"NET", "LAN", "PORT", "LINK", "PEER", "POLL", "TRACE", "TOKEN", "PACKET",
+	"CLIENT", "KERNEL", "TICKET", "OPTICAL", "PRINTER", "REPLICA", "INTRANET",
+	"PIPELINE", "PROTOCOL",
+}
+
+// survivalSeed fixes the survival map, so every run is on the same network
+// and the survival board stays comparable.
+const survivalSeed = 1
+
+var survivalDifficulty = Difficulty{
+	Width:         80,
+	Height:        21,
+	Junctions:     8,
+	Branching:     3,
+	PathLength:    4,
+	SpawnInterval: 3 * time.Second,
+}
+
+// SurvivalLevelData is the map survival runs are played on: one letter for
+// each letter of SurvivalWords.
+func SurvivalLevelData() LevelData {
+	var letters []rune
+	for _, word := range SurvivalWords {
+		for _, letter := range uniqueLetters(word) {
+			if !containsRune(letters, letter) {
+				letters = append(letters, letter)
+			}
+		}
+	}
+
+	rng := rand.New(rand.NewSource(survivalSeed))
+	grid, err := generateGrid(rng, survivalDifficulty, letters, "  SURVIVAL: spell every word that comes along!", string(letters))
+	if err != nil {
+		// The map never changes, so this is a bug.
+		panic(fmt.Sprintf("generating the survival map: %v", err))
+	}
+	return LevelData{
+		Grid:          grid,
+		Junctions:     ParseGrid(grid),
+		SpawnInterval: survivalDifficulty.SpawnInterval,
+		TargetWord:    SurvivalWords[0],
+	}
+}
+
+func containsRune(runes []rune, r rune) bool {
+	for _, c := range runes {
+		if c == r {
+			return true
+		}
+	}
+	return false
+}
+
+// NewSurvivalSimulation starts a survival run. The seed decides the order
+// the words come in and the packets spawned.
+func NewSurvivalSimulation(seed int64) *types.Simulation {
+	sim := NewSimulation(SurvivalLevelData(), 1, seed)
+	sim.StartSurvival(SurvivalWords)
+	return sim
+}
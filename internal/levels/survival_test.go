@@ -0,0 +1,71 @@
+package levels
+
+import (
+	"testing"
+
+	"github.com/maverickkamal/Packet-Rush/internal/types"
+)
+
+func TestSurvivalStreakRaisesTheMultiplier(t *testing.T) {
+	sim := forkSimulation("", false)
+	sim.StartSurvival([]string{"A", "B"})
+	if sim.Lives != types.SurvivalLives || sim.Multiplier() != 1 {
+		t.Fatalf("lives %d, multiplier x%d at the start", sim.Lives, sim.Multiplier())
+	}
+
+	score := 0
+	for wave := 1; wave <= 3; wave++ {
+		letter := []rune(sim.TargetWord)[0]
+		multiplier := sim.Multiplier()
+		deliver(sim, letter)
+		score += (types.CorrectLetterPoints + types.LevelCompleteBonus) * multiplier
+		if sim.Score != score {
+			t.Errorf("wave %d: score = %d, want %d", wave, sim.Score, score)
+		}
+		if sim.WordsCompleted != wave || sim.Multiplier() != wave+1 {
+			t.Errorf("wave %d: %d words, multiplier x%d", wave, sim.WordsCompleted, sim.Multiplier())
+		}
+		if []rune(sim.TargetWord)[0] == letter {
+			t.Errorf("wave %d: the same word came twice in a row", wave)
+		}
+	}
+	if sim.LevelComplete {
+		t.Error("a survival run completed like a level")
+	}
+
+	// A lost packet breaks the streak.
+	sim.Packets = append(sim.Packets, &types.Packet{X: 7, Y: 1, PacketType: 'B', DirX: 1})
+	sim.Step(nil)
+	if sim.Lives != types.SurvivalLives-1 || sim.Multiplier() != 1 {
+		t.Errorf("after a lost packet: lives %d, multiplier x%d", sim.Lives, sim.Multiplier())
+	}
+}
+
+func TestSurvivalMultiplierIsCapped(t *testing.T) {
+	sim := forkSimulation("", false)
+	sim.StartSurvival([]string{"A", "B"})
+	sim.Streak = 100
+	if got := sim.Multiplier(); got != types.MaxStreakMultiplier {
+		t.Errorf("multiplier = x%d, want the cap x%d", got, types.MaxStreakMultiplier)
+	}
+}
+
+func TestSurvivalWavesSpawnFaster(t *testing.T) {
+	sim := forkSimulation("", false)
+	sim.SpawnInterval = survivalDifficulty.SpawnInterval
+	sim.StartSurvival([]string{"A", "B"})
+	deliver(sim, []rune(sim.TargetWord)[0])
+	if want := survivalDifficulty.SpawnInterval - types.SurvivalWaveSpeedup; sim.SpawnInterval != want {
+		t.Errorf("spawn interval = %v after a wave, want %v", sim.SpawnInterval, want)
+	}
+}
+
+func TestSurvivalMapHasEveryLetter(t *testing.T) {
+	data := SurvivalLevelData()
+	for _, word := range SurvivalWords {
+		data.TargetWord = word
+		if issues := Validate(data); HasErrors(issues) {
+			t.Errorf("%s: %v", word, issues)
+		}
+	}
+}
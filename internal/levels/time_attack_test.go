@@ -0,0 +1,66 @@
+package levels
+
+import (
+	"testing"
+
+	"github.com/maverickkamal/Packet-Rush/internal/types"
+)
+
+func timeAttack(word string) *types.Simulation {
+	sim := forkSimulation(word, false)
+	sim.StartTimeAttack()
+	return sim
+}
+
+func TestTimeAttackClockRunsWithTheTicks(t *testing.T) {
+	sim := timeAttack("AB")
+	if want := types.TimeAttackLimit("AB"); sim.TimeLeft != want {
+		t.Fatalf("clock starts at %v, want %v", sim.TimeLeft, want)
+	}
+
+	start := sim.TimeLeft
+	tick := sim.TickSpeed
+	sim.Step(nil)
+	if sim.TimeLeft != start-tick || sim.Elapsed != tick {
+		t.Errorf("after a tick of %v: %v left, %v elapsed", tick, sim.TimeLeft, sim.Elapsed)
+	}
+}
+
+func TestTimeAttackTradesTimeForPackets(t *testing.T) {
+	sim := timeAttack("AB")
+	before := sim.TimeLeft - sim.TickSpeed
+	deliver(sim, 'A')
+	if sim.TimeLeft != before+types.TimeAttackBonus {
+		t.Errorf("a delivery left %v on the clock, want %v", sim.TimeLeft, before+types.TimeAttackBonus)
+	}
+
+	before = sim.TimeLeft - sim.TickSpeed
+	sim.Packets = append(sim.Packets, &types.Packet{X: 7, Y: 1, PacketType: 'B', DirX: 1})
+	sim.Step(nil)
+	if sim.TimeLeft != before-types.TimeAttackPenalty {
+		t.Errorf("a misroute left %v on the clock, want %v", sim.TimeLeft, before-types.TimeAttackPenalty)
+	}
+	if sim.Lives != types.LivesPerLevel {
+		t.Errorf("a misroute cost a life in a time attack: %d left", sim.Lives)
+	}
+}
+
+func TestTimeAttackEndsWhenTheClockRunsOut(t *testing.T) {
+	sim := timeAttack("AB")
+	sim.TimeLeft = sim.TickSpeed
+	sim.Step(nil)
+	if !sim.TimedOut() || !sim.GameOver {
+		t.Errorf("timed out %v, game over %v with %v left", sim.TimedOut(), sim.GameOver, sim.TimeLeft)
+	}
+}
+
+func TestTimeAttackPaysForTimeLeft(t *testing.T) {
+	sim := timeAttack("A")
+	// The bonus is counted on delivery, before the tick comes off the clock.
+	left := sim.TimeLeft + types.TimeAttackBonus
+	deliver(sim, 'A')
+	bonus := int(left.Seconds()) * types.TimeLeftPoints
+	if want := types.CorrectLetterPoints + types.LevelCompleteBonus + bonus; !sim.LevelComplete || sim.Score != want {
+		t.Errorf("score = %d, want %d with %v left", sim.Score, want, left)
+	}
+}
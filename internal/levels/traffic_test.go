@@ -0,0 +1,99 @@
+package levels
+
+import (
+	"testing"
+
+	"github.com/maverickkamal/Packet-Rush/internal/types"
+)
+
+// crossGrid has a line from above joining the line to A at a junction
+// that starts out pointing right.
+var crossGrid = []string{
+	"#######",
+	"#  |  #",
+	"#--+-A#",
+	"#######",
+}
+
+// trafficSimulation is grid spelling "AA" under rule and capacity, with no
+// spawners so only the packets a test places move.
+func trafficSimulation(grid []string, rule types.CollisionRule, capacity int) *types.Simulation {
+	data := LevelData{Grid: grid, Junctions: ParseGrid(grid), TargetWord: "AA", Collisions: rule, LinkCapacity: capacity}
+	sim := NewSimulation(data, 1, 1)
+	sim.Spawners = nil
+	return sim
+}
+
+// converge puts two packets one step away from (3,2) on crossGrid.
+func converge(sim *types.Simulation) (first, second *types.Packet) {
+	first = &types.Packet{X: 2, Y: 2, PacketType: 'A', DirX: 1}
+	second = &types.Packet{X: 3, Y: 1, PacketType: 'A', DirY: 1}
+	sim.Packets = append(sim.Packets, first, second)
+	return first, second
+}
+
+func TestCrashDropsPacketsThatMeet(t *testing.T) {
+	sim := trafficSimulation(crossGrid, types.CollisionsCrash, 0)
+	converge(sim)
+	sim.Step(nil)
+	if len(sim.Packets) != 0 {
+		t.Errorf("%d packets survived meeting in one cell", len(sim.Packets))
+	}
+	if sim.Lives != types.LivesPerLevel-1 {
+		t.Errorf("lives = %d, want one life lost for the crash", sim.Lives)
+	}
+}
+
+func TestCrashDropsPacketsHeadOn(t *testing.T) {
+	sim := trafficSimulation(crossGrid, types.CollisionsCrash, 0)
+	sim.Packets = append(sim.Packets,
+		&types.Packet{X: 2, Y: 2, PacketType: 'A', DirX: 1},
+		&types.Packet{X: 3, Y: 2, PacketType: 'A', DirX: -1})
+	sim.Step(nil)
+	if len(sim.Packets) != 0 || sim.Lives != types.LivesPerLevel-1 {
+		t.Errorf("%d packets left and %d lives after a head-on crash", len(sim.Packets), sim.Lives)
+	}
+}
+
+func TestWaitHoldsPacketBackUntilTheCellIsFree(t *testing.T) {
+	sim := trafficSimulation(crossGrid, types.CollisionsWait, 0)
+	first, second := converge(sim)
+	sim.Step(nil)
+	if first.X != 3 || first.Y != 2 || second.X != 3 || second.Y != 1 {
+		t.Fatalf("after one tick the packets are at (%d,%d) and (%d,%d), want the second waiting",
+			first.X, first.Y, second.X, second.Y)
+	}
+
+	for tick := 0; tick < 5 && !sim.Finished(); tick++ {
+		sim.Step(nil)
+	}
+	if !sim.LevelComplete || sim.Misroutes != 0 {
+		t.Errorf("complete = %v with %d misroutes, want both packets delivered", sim.LevelComplete, sim.Misroutes)
+	}
+}
+
+func TestLinkCapacityHoldsPacketsOffAFullLink(t *testing.T) {
+	// The '+' with no junction on it splits the track into two links.
+	grid := []string{
+		"##########",
+		"#---+---A#",
+		"##########",
+	}
+	sim := trafficSimulation(grid, types.CollisionsOff, 1)
+	ahead := &types.Packet{X: 5, Y: 1, PacketType: 'A', DirX: 1}
+	behind := &types.Packet{X: 4, Y: 1, PacketType: 'A', DirX: 1}
+	sim.Packets = append(sim.Packets, ahead, behind)
+	sim.Junctions = map[string]*types.Junction{}
+
+	for tick := 0; tick < 2; tick++ {
+		sim.Step(nil)
+		if behind.X != 4 {
+			t.Fatalf("tick %d: the packet behind entered a full link", tick+1)
+		}
+	}
+	sim.Step(nil)
+	if len(sim.GoalProgress) != 1 || behind.X != 5 {
+		t.Errorf("progress %q, packet behind at x=%d; want it let on once the link emptied",
+			string(sim.GoalProgress), behind.X)
+	}
+}
@@ -0,0 +1,63 @@
+package levels
+
+import (
+	"testing"
+
+	"github.com/maverickkamal/Packet-Rush/internal/types"
+)
+
+// ttlRun sends a packet with ttl from the left end of crossGrid towards A,
+// four moves and one junction away, and plays until it is gone.
+func ttlRun(ttl int, hops bool) *types.Simulation {
+	sim := trafficSimulation(crossGrid, types.CollisionsOff, 0)
+	sim.TTL, sim.TTLHops = ttl, hops
+	sim.Score = 100
+	sim.Packets = append(sim.Packets, &types.Packet{X: 1, Y: 2, PacketType: 'A', DirX: 1, TTL: ttl})
+	for tick := 0; tick < 10 && len(sim.Packets) > 0; tick++ {
+		sim.Step(nil)
+	}
+	return sim
+}
+
+func TestPacketExpiresWhenItsTTLRunsOut(t *testing.T) {
+	sim := ttlRun(3, false)
+	if len(sim.GoalProgress) != 0 {
+		t.Error("a packet with too few moves left was delivered")
+	}
+	if sim.Score != 100-types.ExpiredPenalty {
+		t.Errorf("score = %d, want %d", sim.Score, 100-types.ExpiredPenalty)
+	}
+	if sim.Lives != types.LivesPerLevel || sim.Misroutes != 0 {
+		t.Errorf("an expired packet cost a life: lives %d, misroutes %d", sim.Lives, sim.Misroutes)
+	}
+}
+
+func TestPacketIsDeliveredWithItsLastMove(t *testing.T) {
+	if sim := ttlRun(4, false); string(sim.GoalProgress) != "A" {
+		t.Errorf("progress %q, want the packet delivered on its last move", string(sim.GoalProgress))
+	}
+}
+
+func TestHopsCountOnlyJunctions(t *testing.T) {
+	if sim := ttlRun(1, true); len(sim.GoalProgress) != 0 {
+		t.Error("a packet with no hops left was delivered past a junction")
+	}
+	if sim := ttlRun(2, true); string(sim.GoalProgress) != "A" {
+		t.Errorf("progress %q, want a packet with hops to spare delivered", string(sim.GoalProgress))
+	}
+}
+
+func TestSpawnedPacketsGetTheLevelTTL(t *testing.T) {
+	data := GetLevelData(1)
+	data.TTL = 7
+	sim := NewSimulation(data, 1, 1)
+	for tick := 0; tick < 100 && len(sim.Packets) == 0; tick++ {
+		sim.Step(nil)
+	}
+	if len(sim.Packets) == 0 {
+		t.Fatal("nothing spawned")
+	}
+	if p := sim.Packets[0]; p.TTL != 7 {
+		t.Errorf("spawned packet has TTL %d, want the level's 7", p.TTL)
+	}
+}
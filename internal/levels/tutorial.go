@@ -0,0 +1,84 @@
+package levels
+
+import "github.com/maverickkamal/Packet-Rush/internal/types"
+
+var tutorialGrid = []string{
+	"################################################################################",
+	"#                                                                              #",
+	"#  TUTORIAL - Spell 'GO'                                                       #",
+	"#                                                                              #",
+	"#S--------+----------G                                                         #",
+	"#         |                                                                    #",
+	"#         |                                                                    #",
+	"#         O                                                                    #",
+	"#                                                                              #",
+	"################################################################################",
+}
+
+// tutorialQueue sends the G first, then gives it time to arrive before the
+// O that needs the junction switched.
+var tutorialQueue = []types.QueuedPacket{{Letter: 'G', Tick: 1}, {Letter: 'O', Tick: 24}}
+
+var (
+	tutorialSpawn    = types.Position{X: 1, Y: 4}
+	tutorialJunction = types.Position{X: 10, Y: 4}
+	tutorialG        = types.Position{X: 21, Y: 4}
+	tutorialO        = types.Position{X: 10, Y: 7}
+)
+
+var tutorialSteps = []types.TutorialStep{
+	{
+		On:      types.Trigger{Kind: types.TriggerStart},
+		Message: "Welcome, router! Packets enter the network here at S and follow the track.",
+		Point:   &tutorialSpawn,
+		Wait:    true,
+	},
+	{
+		On:      types.Trigger{Kind: types.TriggerContinue},
+		Message: "Spell 'GO' by sending each letter to its own port. This is the G port.",
+		Point:   &tutorialG,
+		Wait:    true,
+	},
+	{
+		On:      types.Trigger{Kind: types.TriggerContinue},
+		Message: "Here comes a G. Junction 1 points right (→), towards the G port - just watch it go.",
+		Point:   &tutorialJunction,
+	},
+	{
+		On:      types.Trigger{Kind: types.TriggerDelivered, Letter: 'G'},
+		Message: "G delivered! The next packet is an O, and it has to turn down to the O port.",
+		Point:   &tutorialO,
+	},
+	{
+		On:      types.Trigger{Kind: types.TriggerPacketAt, Cell: types.Position{X: 7, Y: 4}},
+		Message: "Stop! Junction 1 still points right. Press 1 (or click it) to switch it down (↓).",
+		Point:   &tutorialJunction,
+		Wait:    true,
+	},
+	{
+		On:      types.Trigger{Kind: types.TriggerSwitch, Cell: tutorialJunction},
+		Message: "The arrow points down now - off the O goes to finish the word!",
+		Point:   &tutorialO,
+	},
+}
+
+// NewTutorialSimulation starts the tutorial level. It plays the same two
+// packets every time so the script can talk about them.
+func NewTutorialSimulation(seed int64) *types.Simulation {
+	sim := NewSimulation(LevelData{
+		Grid:       tutorialGrid,
+		Junctions:  ParseGrid(tutorialGrid),
+		Goal:       "Tutorial: learn to route packets by spelling 'GO'.",
+		TargetWord: "GO",
+	}, 1, seed)
+	sim.Mode = types.ModeTutorial
+	sim.Queue = tutorialQueue
+	return sim
+}
+
+// NewTutorialModel starts the tutorial with its script running.
+func NewTutorialModel(seed int64) *types.GameModel {
+	m := types.NewGameModel(NewTutorialSimulation(seed))
+	m.StartTutorial(tutorialSteps)
+	return m
+}
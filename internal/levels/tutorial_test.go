@@ -0,0 +1,73 @@
+package levels
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/maverickkamal/Packet-Rush/internal/types"
+)
+
+func press(m *types.GameModel, key string) {
+	msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+	if key == " " {
+		msg = tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(key)}
+	}
+	m.Update(msg)
+}
+
+// tickUntil plays ticks until the tutorial shows text, and fails if it never
+// does.
+func tickUntil(t *testing.T, m *types.GameModel, text string) {
+	t.Helper()
+	for tick := 0; tick < 200 && !m.Finished(); tick++ {
+		if strings.Contains(m.View(), text) {
+			return
+		}
+		m.Update(types.TickMsg{})
+	}
+	if !strings.Contains(m.View(), text) {
+		t.Fatalf("the tutorial never showed %q", text)
+	}
+}
+
+func TestTutorialFollowsItsScript(t *testing.T) {
+	m := NewTutorialModel(1)
+	junction := m.Junctions["10,4"]
+
+	tickUntil(t, m, "Welcome, router!")
+	m.Update(types.TickMsg{})
+	if m.GameTime != 0 {
+		t.Error("the game ran while the welcome waited for a key")
+	}
+	press(m, "1")
+	if junction.ActiveDir != 0 {
+		t.Error("a switch the script didn't ask for went through")
+	}
+
+	press(m, " ")
+	tickUntil(t, m, "This is the G port")
+	press(m, " ")
+	tickUntil(t, m, "Here comes a G")
+	tickUntil(t, m, "G delivered!")
+	tickUntil(t, m, "Stop!")
+
+	frozen := m.GameTime
+	m.Update(types.TickMsg{})
+	if m.GameTime != frozen {
+		t.Error("the game ran on while the script waited for the switch")
+	}
+
+	press(m, "1")
+	if junction.GetActiveDirection() != types.Down {
+		t.Fatal("the switch the script asked for didn't go through")
+	}
+	tickUntil(t, m, "off the O goes")
+	for tick := 0; tick < 200 && !m.Finished(); tick++ {
+		m.Update(types.TickMsg{})
+	}
+	if !m.LevelComplete {
+		t.Errorf("the tutorial ended with %q spelled", string(m.GoalProgress))
+	}
+}
@@ -0,0 +1,212 @@
+package levels
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/maverickkamal/Packet-Rush/internal/types"
+)
+
+type Issue struct {
+	Warning bool
+	Message string
+}
+
+func (i Issue) String() string {
+	if i.Warning {
+		return "warning: " + i.Message
+	}
+	return "error: " + i.Message
+}
+
+type routeState struct {
+	Pos, Dir types.Position
+}
+
+// hasDecoys reports whether any packet the level spawns has a letter that
+// isn't in the word.
+func hasDecoys(data LevelData, spawners []types.Spawner) bool {
+	if data.SpawnTable != nil && len(data.SpawnTable.Decoys) > 0 {
+		return true
+	}
+	for _, sp := range spawners {
+		for _, letter := range sp.Letters {
+			if !strings.ContainsRune(data.TargetWord, letter) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func junctionLabel(j *types.Junction) string {
+	switch {
+	case j.ID != 0:
+		return fmt.Sprintf("junction %c at (%d,%d)", j.ID, j.X, j.Y)
+	case j.IsSwitchable():
+		return fmt.Sprintf("junction at (%d,%d)", j.X, j.Y)
+	default:
+		return fmt.Sprintf("fixed junction at (%d,%d)", j.X, j.Y)
+	}
+}
+
+// isDestination tells letter ports apart from letters in a level's title
+// text by requiring track to lead into them.
+func isDestination(grid []string, x, y int) bool {
+	if !isPort(gridChar(grid, x, y)) {
+		return false
+	}
+	for _, dir := range junctionDirections {
+		c := gridChar(grid, x+dir.X, y+dir.Y)
+		if c == '+' || isTrackFor(c, dir) {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate walks every route a packet can take from each spawn, trying all
+// settings of every junction it passes, and reports what would make the
+// level unwinnable. Routes that crash into a wall are only warnings, since
+// levels use them as traps.
+func Validate(data LevelData) []Issue {
+	var issues []Issue
+	errorf := func(format string, args ...any) {
+		issues = append(issues, Issue{Message: fmt.Sprintf(format, args...)})
+	}
+
+	grid := data.Grid
+	junctions := make([]*types.Junction, 0, len(data.Junctions))
+	for _, j := range data.Junctions {
+		junctions = append(junctions, j)
+	}
+	sort.Slice(junctions, func(a, b int) bool {
+		if junctions[a].Y != junctions[b].Y {
+			return junctions[a].Y < junctions[b].Y
+		}
+		return junctions[a].X < junctions[b].X
+	})
+
+	keys := make(map[rune]*types.Junction)
+	for _, j := range junctions {
+		if gridChar(grid, j.X, j.Y) != '+' {
+			errorf("%s is not on a '+' track cell", junctionLabel(j))
+		}
+		if len(j.Directions) == 0 {
+			errorf("%s has no way out, so packets would stop on it", junctionLabel(j))
+		}
+		if j.ID == 0 {
+			continue
+		}
+		if other, exists := keys[j.ID]; exists {
+			errorf("junction key %c is used at both (%d,%d) and (%d,%d)", j.ID, other.X, other.Y, j.X, j.Y)
+			continue
+		}
+		keys[j.ID] = j
+	}
+
+	spawners := data.Spawners
+	if spawners == nil {
+		spawners = DetectSpawners(grid)
+	}
+	var stack []routeState
+	for _, sp := range spawners {
+		stack = append(stack, routeState{Pos: types.Position{X: sp.X, Y: sp.Y}, Dir: sp.Dir})
+	}
+	if len(stack) == 0 {
+		errorf("no spawn 'S' feeds track in from a wall")
+	}
+
+	visited := make(map[routeState]bool)
+	onRoute := make(map[types.Position]bool)
+	reached := make(map[byte]bool)
+	deadEnds := make(map[types.Position]bool)
+	for len(stack) > 0 {
+		state := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if visited[state] {
+			continue
+		}
+		visited[state] = true
+
+		pos := state.Pos.Add(state.Dir)
+		next := []types.Position{state.Dir}
+		if j, exists := data.Junctions[fmt.Sprintf("%d,%d", pos.X, pos.Y)]; exists {
+			onRoute[pos] = true
+			next = j.Directions
+		}
+
+		c := gridChar(grid, pos.X, pos.Y)
+		switch {
+		case c == '#' || len(next) == 0:
+			deadEnds[pos] = true
+		case types.IsPort(rune(c)):
+			reached[c] = true
+		default:
+			for _, dir := range next {
+				stack = append(stack, routeState{Pos: pos, Dir: dir})
+			}
+		}
+	}
+
+	checked := make(map[byte]bool)
+	for _, letter := range []byte(data.TargetWord) {
+		if checked[letter] || reached[letter] {
+			continue
+		}
+		checked[letter] = true
+
+		found := false
+		for y, row := range grid {
+			for x := 0; x < len(row); x++ {
+				if row[x] == letter && isDestination(grid, x, y) {
+					found = true
+				}
+			}
+		}
+		if found {
+			errorf("letter %c cannot be reached from any spawn", letter)
+		} else {
+			errorf("letter %c has no destination in the grid", letter)
+		}
+	}
+
+	if hasDecoys(data, spawners) && !reached[types.Sink] {
+		errorf("decoy packets have no sink '%c' they can reach from a spawn", types.Sink)
+	}
+
+	for _, j := range junctions {
+		if !onRoute[types.Position{X: j.X, Y: j.Y}] {
+			errorf("%s is not on any route from a spawn", junctionLabel(j))
+		}
+	}
+
+	ends := make([]types.Position, 0, len(deadEnds))
+	for pos := range deadEnds {
+		ends = append(ends, pos)
+	}
+	sort.Slice(ends, func(a, b int) bool {
+		if ends[a].Y != ends[b].Y {
+			return ends[a].Y < ends[b].Y
+		}
+		return ends[a].X < ends[b].X
+	})
+	for _, pos := range ends {
+		issues = append(issues, Issue{
+			Warning: true,
+			Message: fmt.Sprintf("a route dead-ends at (%d,%d) without reaching a letter", pos.X, pos.Y),
+		})
+	}
+
+	return issues
+}
+
+func HasErrors(issues []Issue) bool {
+	for _, issue := range issues {
+		if !issue.Warning {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,172 @@
+package levels
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/maverickkamal/Packet-Rush/internal/types"
+)
+
+var forkGrid = []string{
+	"##########",
+	"#S--+---A#",
+	"#   |    #",
+	"#   B    #",
+	"##########",
+}
+
+func TestParseGridFindsJunctions(t *testing.T) {
+	junctions := ParseGrid(forkGrid)
+	if len(junctions) != 1 {
+		t.Fatalf("got %d junctions, want 1", len(junctions))
+	}
+	j := junctions["4,1"]
+	if j == nil {
+		t.Fatal("no junction at (4,1)")
+	}
+	if j.ID != '1' {
+		t.Errorf("junction key = %c, want 1", j.ID)
+	}
+	if want := []types.Position{types.Right, types.Down}; !reflect.DeepEqual(j.Directions, want) {
+		t.Errorf("directions = %v, want %v", j.Directions, want)
+	}
+}
+
+// mergeGrid joins the lines of two spawns at the same distance from each.
+var mergeGrid = []string{
+	"###########",
+	"#S-----+-A#",
+	"#      |  #",
+	"#S-----+  #",
+	"###########",
+}
+
+func TestParseGridSendsMergesOnward(t *testing.T) {
+	junctions := ParseGrid(mergeGrid)
+	want := map[string][]types.Position{
+		"7,1": {types.Right},
+		"7,3": {types.Up},
+	}
+	for key, dirs := range want {
+		j := junctions[key]
+		if j == nil {
+			t.Fatalf("no junction at %s", key)
+		}
+		if !reflect.DeepEqual(j.Directions, dirs) {
+			t.Errorf("junction at %s: directions = %v, want %v", key, j.Directions, dirs)
+		}
+	}
+
+	data := LevelData{Grid: mergeGrid, Junctions: junctions, TargetWord: "A"}
+	if issues := Validate(data); HasErrors(issues) {
+		t.Errorf("merge grid has errors: %v", issues)
+	}
+}
+
+func TestValidateReportsJunctionWithNoWayOut(t *testing.T) {
+	junctions := ParseGrid(mergeGrid)
+	junctions["7,3"] = types.NewJunction(7, 3, nil, 0)
+	issues := Validate(LevelData{Grid: mergeGrid, Junctions: junctions, TargetWord: "A"})
+	found := false
+	for _, issue := range issues {
+		if !issue.Warning && strings.Contains(issue.Message, "(7,3) has no way out") {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("no error for the junction with no way out: %v", issues)
+	}
+}
+
+func TestJunctionsPastNineHaveNoKey(t *testing.T) {
+	if key := types.JunctionKey(8); key != '9' {
+		t.Errorf("ninth key = %c, want 9", key)
+	}
+	if key := types.JunctionKey(9); key != 0 {
+		t.Errorf("tenth key = %c, want none", key)
+	}
+}
+
+func TestValidateBuiltinLevels(t *testing.T) {
+	for level := 1; level <= types.MaxLevel; level++ {
+		for _, issue := range Validate(BuiltinLevelData(level)) {
+			if !issue.Warning {
+				t.Errorf("level %d: %s", level, issue)
+			}
+		}
+	}
+}
+
+func TestValidateReportsUnreachableLetter(t *testing.T) {
+	data := LevelData{Grid: forkGrid, Junctions: ParseGrid(forkGrid), TargetWord: "AC"}
+	issues := Validate(data)
+	if !HasErrors(issues) {
+		t.Fatal("a word with a letter missing from the grid validated")
+	}
+	found := false
+	for _, issue := range issues {
+		found = found || strings.Contains(issue.Message, "letter C")
+	}
+	if !found {
+		t.Errorf("no issue names letter C: %v", issues)
+	}
+
+	data.TargetWord = "AB"
+	if issues := Validate(data); HasErrors(issues) {
+		t.Errorf("a reachable word failed to validate: %v", issues)
+	}
+}
+
+func TestLevelFileRoundTrip(t *testing.T) {
+	file := LevelFile{
+		Level:         3,
+		Grid:          forkGrid,
+		Junctions:     []JunctionFile{{X: 4, Y: 1, ID: "2", Directions: []string{"down", "right"}}},
+		SpawnInterval: "1500ms",
+		Goal:          "Spell AB",
+		TargetWord:    "AB",
+		Ordered:       true,
+		Collisions:    "wait",
+		LinkCapacity:  2,
+		TTL:           30,
+	}
+	encoded, err := json.Marshal(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded LevelFile
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := decoded.LevelData()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data.SpawnInterval != 1500*time.Millisecond || !data.Ordered || data.Collisions != types.CollisionsWait ||
+		data.LinkCapacity != 2 || data.TTL != 30 {
+		t.Errorf("settings didn't survive the round trip: %+v", data)
+	}
+	j := data.Junctions["4,1"]
+	if j == nil || j.ID != '2' || !reflect.DeepEqual(j.Directions, []types.Position{types.Down, types.Right}) {
+		t.Errorf("junction didn't survive the round trip: %v", j)
+	}
+	if issues := Validate(data); HasErrors(issues) {
+		t.Errorf("round-tripped level failed to validate: %v", issues)
+	}
+}
+
+func TestLevelFileRejectsBadJunctionKey(t *testing.T) {
+	file := LevelFile{
+		Level:      1,
+		Grid:       forkGrid,
+		Junctions:  []JunctionFile{{X: 4, Y: 1, ID: "f", Directions: []string{"right", "down"}}},
+		TargetWord: "AB",
+	}
+	if _, err := file.LevelData(); err == nil {
+		t.Error("a junction keyed f loaded, but f opens the jump labels")
+	}
+}
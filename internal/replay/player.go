@@ -0,0 +1,230 @@
+package replay
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/maverickkamal/Packet-Rush/internal/levels"
+	"github.com/maverickkamal/Packet-Rush/internal/types"
+)
+
+var playbackSpeeds = []int{1, 2, 4, 8, 16}
+
+type tickMsg struct {
+	gen int
+}
+
+// Player re-simulates a replay through the normal game renderer. Switches
+// are fed back in on their recorded ticks and every spawn is checked against
+// the recording, so a mismatch shows up as a desync instead of a wrong game.
+type Player struct {
+	replay *Replay
+	run    int
+	sim    *types.Simulation
+	view   *types.GameModel
+	next   int
+
+	spawned  []types.Event
+	playing  bool
+	finished bool
+	desynced bool
+	speed    int
+	gen      int
+
+	width, height int
+}
+
+func NewPlayer(r *Replay) *Player {
+	p := &Player{replay: r, playing: true}
+	p.load(0)
+	return p
+}
+
+func (p *Player) load(run int) {
+	recorded := p.replay.Runs[run]
+	p.run = run
+	p.next = 0
+	if recorded.Start != nil {
+		// Load has already checked that the save restores.
+		p.sim, _ = recorded.Start.Restore()
+	} else {
+		p.sim = levels.NewSimulationForMode(recorded.Mode, recorded.Level, recorded.Seed)
+		p.sim.Score = recorded.Score
+		p.sim.Ordered = recorded.Ordered
+	}
+	p.view = types.NewGameModel(p.sim)
+	p.resize()
+	p.sim.OnEvent = func(e types.Event) {
+		if e.Kind == types.EventSpawn {
+			p.spawned = append(p.spawned, e)
+		}
+	}
+}
+
+func (p *Player) rewind() {
+	p.load(0)
+	p.finished = false
+	p.desynced = false
+}
+
+// nextRun moves on to the next recorded run, or ends playback after the
+// last one.
+func (p *Player) nextRun() {
+	if p.run+1 < len(p.replay.Runs) {
+		p.load(p.run + 1)
+	} else {
+		p.finished = true
+		p.playing = false
+	}
+}
+
+func (p *Player) step() {
+	if p.sim.Finished() {
+		p.nextRun()
+		return
+	}
+
+	events := p.replay.Runs[p.run].Events
+	for p.next < len(events) && events[p.next].Tick <= p.sim.GameTime && events[p.next].Kind != types.EventSpawn {
+		// Pauses are played back too: a puzzle starts stopped, and only
+		// its recorded pauses run it. The game clock stands still while
+		// paused, so a pause and its resume share a tick.
+		switch e := events[p.next]; e.Kind {
+		case types.EventSwitch:
+			p.sim.Apply(types.Input{Kind: types.InputSwitch, X: e.X, Y: e.Y})
+		case types.EventHint:
+			p.sim.Apply(types.Input{Kind: types.InputHint})
+		case types.EventPause:
+			p.sim.Apply(types.Input{Kind: types.InputPause})
+		}
+		p.next++
+	}
+
+	// A run that was left paused for good, such as a puzzle given up on
+	// before it was run, has nothing more to play.
+	if p.sim.Paused && p.next >= len(events) {
+		p.nextRun()
+		return
+	}
+
+	p.spawned = p.spawned[:0]
+	p.sim.Step(nil)
+
+	matched := 0
+	for p.next < len(events) && events[p.next].Kind == types.EventSpawn && events[p.next].Tick <= p.sim.GameTime {
+		e := events[p.next]
+		if matched >= len(p.spawned) || string(p.spawned[matched].Letter) != e.Letter ||
+			p.spawned[matched].X != e.X || p.spawned[matched].Y != e.Y {
+			p.desynced = true
+		}
+		matched++
+		p.next++
+	}
+	if matched != len(p.spawned) {
+		p.desynced = true
+	}
+}
+
+// resize leaves room below the board for the two playback lines.
+func (p *Player) resize() {
+	if p.width > 0 {
+		p.view.SetSize(p.width, p.height-2)
+	}
+}
+
+func (p *Player) tick() tea.Cmd {
+	p.gen++
+	gen := p.gen
+	interval := p.sim.TickSpeed / time.Duration(playbackSpeeds[p.speed])
+	return tea.Tick(interval, func(time.Time) tea.Msg {
+		return tickMsg{gen: gen}
+	})
+}
+
+func (p *Player) Init() tea.Cmd {
+	return p.tick()
+}
+
+func (p *Player) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		p.width, p.height = msg.Width, msg.Height
+		p.resize()
+
+	case tickMsg:
+		if msg.gen != p.gen || !p.playing {
+			return p, nil
+		}
+		p.step()
+		if p.playing {
+			return p, p.tick()
+		}
+		return p, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "q":
+			return p, tea.Quit
+
+		case " ":
+			if p.finished {
+				return p, nil
+			}
+			p.playing = !p.playing
+			if p.playing {
+				return p, p.tick()
+			}
+			return p, nil
+
+		case ".", "n":
+			p.playing = false
+			if !p.finished {
+				p.step()
+			}
+			return p, nil
+
+		case "f":
+			p.speed = (p.speed + 1) % len(playbackSpeeds)
+			if p.playing {
+				return p, p.tick()
+			}
+			return p, nil
+
+		case "0", "home":
+			p.rewind()
+			p.playing = true
+			return p, p.tick()
+		}
+	}
+
+	return p, nil
+}
+
+func (p *Player) View() string {
+	var builder strings.Builder
+
+	builder.WriteString(p.view.View())
+
+	state := "▶ PLAYING"
+	if p.finished {
+		state = "■ END OF REPLAY"
+	} else if !p.playing {
+		state = "⏸️  PAUSED"
+	}
+	builder.WriteString(fmt.Sprintf(types.BgMagenta+types.ColorWhite+types.ColorBright+" REPLAY "+types.ColorReset+" %s │ Run %d/%d │ Tick %d │ Speed %dx",
+		state, p.run+1, len(p.replay.Runs), p.sim.GameTime, playbackSpeeds[p.speed]))
+	if p.desynced {
+		builder.WriteString(" │ " + types.ColorRed + types.ColorBright + "DESYNC: spawns differ from the recording" + types.ColorReset)
+	}
+	builder.WriteString("\n")
+
+	builder.WriteString(types.ColorWhite + "Replay: " + types.ColorYellow + "[SPACE]" + types.ColorWhite + " Play/Pause │ " +
+		types.ColorGreen + "[.]" + types.ColorWhite + " Step │ " +
+		types.ColorCyan + "[F]" + types.ColorWhite + " Fast-forward │ " +
+		types.ColorMagenta + "[0]" + types.ColorWhite + " Rewind │ " +
+		types.ColorRed + "[Q]" + types.ColorWhite + " Quit" + types.ColorReset + "\n")
+
+	return builder.String()
+}
@@ -0,0 +1,120 @@
+package replay
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/maverickkamal/Packet-Rush/internal/savegame"
+	"github.com/maverickkamal/Packet-Rush/internal/types"
+)
+
+// FormatVersion 2 added runs continued from a saved game and 3 whether a run
+// was spelled in order. Older replays have neither and still play back.
+const FormatVersion = 3
+
+// Replay is a recorded session: one run per level played, each of which can
+// be rebuilt from its level number and seed, or from the saved game it was
+// continued from, and re-driven by its events.
+type Replay struct {
+	Version int        `json:"version"`
+	Runs    []LevelRun `json:"runs"`
+}
+
+type LevelRun struct {
+	Mode   types.Mode `json:"mode,omitempty"`
+	Level  int        `json:"level"`
+	Seed   int64      `json:"seed"`
+	Score  int        `json:"score"`
+	Events []Event    `json:"events"`
+
+	// Ordered is whether the run counted letters only in spelling order,
+	// which the level alone doesn't say when it was turned on for a game.
+	Ordered bool `json:"ordered,omitempty"`
+
+	// Start is the saved game a continued run picked up from.
+	Start *savegame.SaveGame `json:"start,omitempty"`
+}
+
+type Event struct {
+	Tick   int             `json:"tick"`
+	Kind   types.EventKind `json:"kind"`
+	X      int             `json:"x,omitempty"`
+	Y      int             `json:"y,omitempty"`
+	Letter string          `json:"letter,omitempty"`
+}
+
+func Load(path string) (*Replay, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var r Replay
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if r.Version < 1 || r.Version > FormatVersion {
+		return nil, fmt.Errorf("%s: unsupported replay version %d", path, r.Version)
+	}
+	if len(r.Runs) == 0 {
+		return nil, fmt.Errorf("%s: replay has no runs", path)
+	}
+	for i, run := range r.Runs {
+		if run.Start == nil {
+			continue
+		}
+		if _, err := run.Start.Restore(); err != nil {
+			return nil, fmt.Errorf("%s: run %d: %w", path, i+1, err)
+		}
+	}
+	return &r, nil
+}
+
+func (r *Replay) Save(path string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+type Recorder struct {
+	Replay Replay
+}
+
+func NewRecorder() *Recorder {
+	return &Recorder{Replay: Replay{Version: FormatVersion}}
+}
+
+// Attach starts a new run for sim and records its events from now on. Call
+// it after any carried-over state such as the score has been set.
+func (r *Recorder) Attach(sim *types.Simulation) {
+	r.attach(sim, nil)
+}
+
+// AttachContinued starts a new run for sim, just restored from save, which
+// playback starts from instead of the level's first tick.
+func (r *Recorder) AttachContinued(sim *types.Simulation, save savegame.SaveGame) {
+	r.attach(sim, &save)
+}
+
+func (r *Recorder) attach(sim *types.Simulation, start *savegame.SaveGame) {
+	r.Replay.Runs = append(r.Replay.Runs, LevelRun{
+		Mode:    sim.Mode,
+		Level:   sim.Level,
+		Seed:    sim.Seed,
+		Score:   sim.Score,
+		Ordered: sim.Ordered,
+		Start:   start,
+	})
+	index := len(r.Replay.Runs) - 1
+
+	sim.OnEvent = func(e types.Event) {
+		event := Event{Tick: e.Tick, Kind: e.Kind, X: e.X, Y: e.Y}
+		if e.Letter != 0 {
+			event.Letter = string(e.Letter)
+		}
+		r.Replay.Runs[index].Events = append(r.Replay.Runs[index].Events, event)
+	}
+}
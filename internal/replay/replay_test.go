@@ -0,0 +1,123 @@
+package replay
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/maverickkamal/Packet-Rush/internal/levels"
+	"github.com/maverickkamal/Packet-Rush/internal/savegame"
+	"github.com/maverickkamal/Packet-Rush/internal/types"
+)
+
+// playBack steps a player through every run of r and returns the last one.
+func playBack(t *testing.T, r *Replay) *Player {
+	t.Helper()
+	p := NewPlayer(r)
+	for i := 0; i < 10000 && !p.finished; i++ {
+		p.step()
+	}
+	if !p.finished {
+		t.Fatal("playback never finished")
+	}
+	if p.desynced {
+		t.Error("playback desynced from the recording")
+	}
+	return p
+}
+
+func TestPlayerMatchesRecording(t *testing.T) {
+	m := levels.NewGameModelForMode(types.ModeCampaign, 2)
+	rec := NewRecorder()
+	rec.Attach(m.Simulation)
+	for i := 0; i < 3000 && !m.Finished(); i++ {
+		m.Step(m.AutopilotInputs())
+	}
+	if !m.Finished() {
+		t.Fatal("the recorded game never finished")
+	}
+
+	path := filepath.Join(t.TempDir(), "run.json")
+	if err := rec.Replay.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p := playBack(t, loaded)
+	if p.sim.GameTime != m.GameTime || p.sim.Score != m.Score || p.sim.LevelComplete != m.LevelComplete {
+		t.Errorf("playback ended at tick %d with %d points, the game at tick %d with %d",
+			p.sim.GameTime, p.sim.Score, m.GameTime, m.Score)
+	}
+}
+
+func TestPlayerSpellsOrderedRunsInOrder(t *testing.T) {
+	m := levels.NewGameModelForMode(types.ModeCampaign, 4)
+	m.Ordered = true
+	rec := NewRecorder()
+	rec.Attach(m.Simulation)
+	for i := 0; i < 3000 && !m.Finished(); i++ {
+		m.Step(m.AutopilotInputs())
+	}
+	if !m.Finished() {
+		t.Fatal("the recorded game never finished")
+	}
+
+	p := playBack(t, &rec.Replay)
+	if !p.sim.Ordered {
+		t.Error("playback didn't spell in order")
+	}
+	if p.sim.GameTime != m.GameTime || p.sim.Score != m.Score || p.sim.Misroutes != m.Misroutes {
+		t.Errorf("ordered playback ended at tick %d with %d points and %d misroutes, the game at tick %d with %d and %d",
+			p.sim.GameTime, p.sim.Score, p.sim.Misroutes, m.GameTime, m.Score, m.Misroutes)
+	}
+}
+
+func TestPlayerRunsPuzzlePauses(t *testing.T) {
+	m := levels.NewGameModelForMode(types.ModePuzzle, 1)
+	rec := NewRecorder()
+	rec.Attach(m.Simulation)
+
+	// Run the puzzle, stop it a few ticks in, then run it to the end.
+	m.Apply(types.Input{Kind: types.InputPause})
+	for i := 0; i < 3; i++ {
+		m.Step(nil)
+	}
+	m.Apply(types.Input{Kind: types.InputPause})
+	m.Apply(types.Input{Kind: types.InputPause})
+	for i := 0; i < 500 && !m.Finished(); i++ {
+		m.Step(nil)
+	}
+
+	p := playBack(t, &rec.Replay)
+	if p.sim.GameTime != m.GameTime || !p.sim.Finished() {
+		t.Errorf("puzzle playback stopped at tick %d, the game ran to %d", p.sim.GameTime, m.GameTime)
+	}
+}
+
+func TestPlayerStartsContinuedRunFromSave(t *testing.T) {
+	before := levels.NewGameModelForMode(types.ModeCampaign, 3)
+	for i := 0; i < 40; i++ {
+		before.Step(before.AutopilotInputs())
+	}
+	save := savegame.Capture(before.Simulation, 0)
+
+	sim, err := save.Restore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := types.NewGameModel(sim)
+	rec := NewRecorder()
+	rec.AttachContinued(sim, save)
+	m.Apply(types.Input{Kind: types.InputPause})
+	for i := 0; i < 3000 && !m.Finished(); i++ {
+		m.Step(m.AutopilotInputs())
+	}
+
+	p := playBack(t, &rec.Replay)
+	if p.sim.GameTime != m.GameTime || p.sim.Score != m.Score {
+		t.Errorf("continued playback ended at tick %d with %d points, the game at tick %d with %d",
+			p.sim.GameTime, p.sim.Score, m.GameTime, m.Score)
+	}
+}
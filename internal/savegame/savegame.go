@@ -0,0 +1,378 @@
+package savegame
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+	"unicode/utf8"
+
+	"github.com/maverickkamal/Packet-Rush/internal/types"
+)
+
+// FormatVersion goes up with every change to what a save holds, so an old
+// save is turned down rather than restored with parts of it missing.
+const FormatVersion = 4
+
+// SaveGame is a complete snapshot of a level in progress. The grid and
+// junctions are stored in full so a save restores even if the level it came
+// from has since been edited.
+type SaveGame struct {
+	Version int `json:"version"`
+
+	Mode           types.Mode `json:"mode,omitempty"`
+	Words          []string   `json:"words,omitempty"`
+	Streak         int        `json:"streak,omitempty"`
+	WordsCompleted int        `json:"words_completed,omitempty"`
+
+	// Queue is what is left of a puzzle's packets, Par its fewest switches.
+	Queue    []QueuedState `json:"queue,omitempty"`
+	Par      int           `json:"par,omitempty"`
+	Switches int           `json:"switches,omitempty"`
+
+	Level     int             `json:"level"`
+	Grid      []string        `json:"grid"`
+	Junctions []JunctionState `json:"junctions"`
+	Packets   []PacketState   `json:"packets"`
+	Spawners  []SpawnerState  `json:"spawners,omitempty"`
+
+	SpawnTable    *SpawnTableState `json:"spawn_table,omitempty"`
+	RecentLetters string           `json:"recent_letters,omitempty"`
+
+	Score         int           `json:"score"`
+	Lives         int           `json:"lives"`
+	Misroutes     int           `json:"misroutes,omitempty"`
+	GameTime      int           `json:"game_time"`
+	Elapsed       time.Duration `json:"elapsed,omitempty"`
+	TimeLeft      time.Duration `json:"time_left,omitempty"`
+	LevelComplete bool          `json:"level_complete"`
+
+	SpawnInterval time.Duration `json:"spawn_interval"`
+	TickSpeed     time.Duration `json:"tick_speed"`
+
+	CurrentGoal  string `json:"goal"`
+	GoalProgress string `json:"goal_progress"`
+	TargetWord   string `json:"target_word"`
+	Ordered      bool   `json:"ordered,omitempty"`
+
+	Collisions   types.CollisionRule `json:"collisions,omitempty"`
+	LinkCapacity int                 `json:"link_capacity,omitempty"`
+	TTL          int                 `json:"ttl,omitempty"`
+	TTLHops      bool                `json:"ttl_hops,omitempty"`
+
+	Seed      int64  `json:"seed"`
+	RandSeed  int64  `json:"rand_seed"`
+	RandDraws uint64 `json:"rand_draws"`
+
+	// LevelStartScore is the score the level was entered with, so level
+	// bests still count only the points earned in it after resuming.
+	LevelStartScore int `json:"level_start_score"`
+
+	// AutopilotUsed carries over that the autopilot played part of the
+	// level, so continuing it doesn't make it count for records again.
+	AutopilotUsed bool `json:"autopilot_used,omitempty"`
+}
+
+type JunctionState struct {
+	X          int      `json:"x"`
+	Y          int      `json:"y"`
+	ID         string   `json:"id,omitempty"`
+	Directions []string `json:"directions"`
+	ActiveDir  int      `json:"active_dir"`
+}
+
+// SpawnerState is a spawner and how long ago it last sent a packet.
+type SpawnerState struct {
+	X              int           `json:"x"`
+	Y              int           `json:"y"`
+	Direction      string        `json:"direction"`
+	Interval       time.Duration `json:"interval,omitempty"`
+	Letters        string        `json:"letters,omitempty"`
+	Delay          time.Duration `json:"delay,omitempty"`
+	SinceLastSpawn time.Duration `json:"since_last_spawn"`
+}
+
+type SpawnTableState struct {
+	Letters []WeightState `json:"letters,omitempty"`
+	Decoys  []WeightState `json:"decoys,omitempty"`
+	Window  int           `json:"window,omitempty"`
+}
+
+type WeightState struct {
+	Letter string `json:"letter"`
+	Weight int    `json:"weight"`
+}
+
+type QueuedState struct {
+	Letter  string `json:"letter"`
+	Tick    int    `json:"tick"`
+	Spawner int    `json:"spawner,omitempty"`
+}
+
+type PacketState struct {
+	X      int    `json:"x"`
+	Y      int    `json:"y"`
+	Letter string `json:"letter"`
+	Dest   int    `json:"dest"`
+	DirX   int    `json:"dir_x"`
+	DirY   int    `json:"dir_y"`
+	Decoy  bool   `json:"decoy,omitempty"`
+	TTL    int    `json:"ttl,omitempty"`
+}
+
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "packet-rush", "savegame.json"), nil
+}
+
+func Capture(sim *types.Simulation, levelStartScore int) SaveGame {
+	randSeed, randDraws := sim.Rand.State()
+	save := SaveGame{
+		Version:         FormatVersion,
+		Mode:            sim.Mode,
+		Words:           sim.Words,
+		Streak:          sim.Streak,
+		WordsCompleted:  sim.WordsCompleted,
+		Par:             sim.Par,
+		Switches:        sim.Switches,
+		Level:           sim.Level,
+		Grid:            sim.Grid,
+		Score:           sim.Score,
+		Lives:           sim.Lives,
+		Misroutes:       sim.Misroutes,
+		GameTime:        sim.GameTime,
+		Elapsed:         sim.Elapsed,
+		TimeLeft:        sim.TimeLeft,
+		LevelComplete:   sim.LevelComplete,
+		SpawnInterval:   sim.SpawnInterval,
+		TickSpeed:       sim.TickSpeed,
+		CurrentGoal:     sim.CurrentGoal,
+		GoalProgress:    string(sim.GoalProgress),
+		TargetWord:      sim.TargetWord,
+		RecentLetters:   string(sim.RecentLetters),
+		Ordered:         sim.Ordered,
+		Collisions:      sim.Collisions,
+		LinkCapacity:    sim.LinkCapacity,
+		TTL:             sim.TTL,
+		TTLHops:         sim.TTLHops,
+		Seed:            sim.Seed,
+		RandSeed:        randSeed,
+		RandDraws:       randDraws,
+		LevelStartScore: levelStartScore,
+	}
+
+	for _, j := range sim.SortedJunctions() {
+		state := JunctionState{X: j.X, Y: j.Y, ActiveDir: j.ActiveDir}
+		if j.ID != 0 {
+			state.ID = string(j.ID)
+		}
+		for _, dir := range j.Directions {
+			state.Directions = append(state.Directions, types.DirectionName(dir))
+		}
+		save.Junctions = append(save.Junctions, state)
+	}
+
+	for _, sp := range sim.Spawners {
+		save.Spawners = append(save.Spawners, SpawnerState{
+			X:              sp.X,
+			Y:              sp.Y,
+			Direction:      types.DirectionName(sp.Dir),
+			Interval:       sp.Interval,
+			Letters:        sp.Letters,
+			Delay:          sp.Delay,
+			SinceLastSpawn: sim.Clock.Now().Sub(sp.LastSpawn),
+		})
+	}
+
+	if t := sim.SpawnTable; t != nil {
+		save.SpawnTable = &SpawnTableState{
+			Letters: captureWeights(t.Letters),
+			Decoys:  captureWeights(t.Decoys),
+			Window:  t.Window,
+		}
+	}
+
+	for _, queued := range sim.Queue {
+		save.Queue = append(save.Queue, QueuedState{Letter: string(queued.Letter), Tick: queued.Tick, Spawner: queued.Spawner})
+	}
+
+	for _, p := range sim.Packets {
+		save.Packets = append(save.Packets, PacketState{
+			X:      p.X,
+			Y:      p.Y,
+			Letter: string(p.PacketType),
+			Dest:   p.Dest,
+			DirX:   p.DirX,
+			DirY:   p.DirY,
+			Decoy:  p.Decoy,
+			TTL:    p.TTL,
+		})
+	}
+
+	return save
+}
+
+// Restore rebuilds the simulation. It comes back paused so the player can
+// get their bearings before the packets start moving again.
+func (s SaveGame) Restore() (*types.Simulation, error) {
+	clock := types.NewManualClock(time.Time{})
+	sim := &types.Simulation{
+		Grid:           s.Grid,
+		Packets:        make([]*types.Packet, 0, len(s.Packets)),
+		Junctions:      make(map[string]*types.Junction),
+		Score:          s.Score,
+		Lives:          s.Lives,
+		Misroutes:      s.Misroutes,
+		Level:          s.Level,
+		GameTime:       s.GameTime,
+		Elapsed:        s.Elapsed,
+		TimeLeft:       s.TimeLeft,
+		Paused:         true,
+		LevelComplete:  s.LevelComplete,
+		SpawnInterval:  s.SpawnInterval,
+		TickSpeed:      s.TickSpeed,
+		CurrentGoal:    s.CurrentGoal,
+		GoalProgress:   []rune(s.GoalProgress),
+		TargetWord:     s.TargetWord,
+		RecentLetters:  []rune(s.RecentLetters),
+		Ordered:        s.Ordered,
+		Collisions:     s.Collisions,
+		LinkCapacity:   s.LinkCapacity,
+		TTL:            s.TTL,
+		TTLHops:        s.TTLHops,
+		Mode:           s.Mode,
+		Words:          s.Words,
+		Streak:         s.Streak,
+		WordsCompleted: s.WordsCompleted,
+		Par:            s.Par,
+		Switches:       s.Switches,
+		Seed:           s.Seed,
+		Clock:          clock,
+		Rand:           types.RestoreRand(s.RandSeed, s.RandDraws),
+	}
+
+	for _, state := range s.Junctions {
+		directions := make([]types.Position, 0, len(state.Directions))
+		for _, name := range state.Directions {
+			dir, ok := types.ParseDirection(name)
+			if !ok {
+				return nil, fmt.Errorf("junction at (%d,%d) has unknown direction %q", state.X, state.Y, name)
+			}
+			directions = append(directions, dir)
+		}
+		if state.ActiveDir < 0 || (len(directions) > 0 && state.ActiveDir >= len(directions)) {
+			return nil, fmt.Errorf("junction at (%d,%d) has active route %d of %d", state.X, state.Y, state.ActiveDir, len(directions))
+		}
+
+		id, _ := utf8.DecodeRuneInString(state.ID)
+		if state.ID == "" {
+			id = 0
+		}
+		junction := types.NewJunction(state.X, state.Y, directions, id)
+		junction.ActiveDir = state.ActiveDir
+		sim.Junctions[fmt.Sprintf("%d,%d", state.X, state.Y)] = junction
+	}
+
+	for _, state := range s.Spawners {
+		dir, ok := types.ParseDirection(state.Direction)
+		if !ok {
+			return nil, fmt.Errorf("spawner at (%d,%d) has unknown direction %q", state.X, state.Y, state.Direction)
+		}
+		sim.Spawners = append(sim.Spawners, &types.Spawner{
+			X:         state.X,
+			Y:         state.Y,
+			Dir:       dir,
+			Interval:  state.Interval,
+			Letters:   state.Letters,
+			Delay:     state.Delay,
+			LastSpawn: clock.Now().Add(-state.SinceLastSpawn),
+		})
+	}
+
+	if t := s.SpawnTable; t != nil {
+		sim.SpawnTable = &types.SpawnTable{
+			Letters: restoreWeights(t.Letters),
+			Decoys:  restoreWeights(t.Decoys),
+			Window:  t.Window,
+		}
+	}
+
+	for _, state := range s.Queue {
+		letter, _ := utf8.DecodeRuneInString(state.Letter)
+		sim.Queue = append(sim.Queue, types.QueuedPacket{Letter: letter, Tick: state.Tick, Spawner: state.Spawner})
+	}
+
+	for _, state := range s.Packets {
+		letter, _ := utf8.DecodeRuneInString(state.Letter)
+		packet := types.NewPacket(state.X, state.Y, letter)
+		packet.Dest = state.Dest
+		packet.SetDirection(state.DirX, state.DirY)
+		packet.Decoy = state.Decoy
+		packet.TTL = state.TTL
+		sim.Packets = append(sim.Packets, packet)
+	}
+
+	return sim, nil
+}
+
+func captureWeights(weights []types.LetterWeight) []WeightState {
+	states := make([]WeightState, 0, len(weights))
+	for _, w := range weights {
+		states = append(states, WeightState{Letter: string(w.Letter), Weight: w.Weight})
+	}
+	return states
+}
+
+func restoreWeights(states []WeightState) []types.LetterWeight {
+	weights := make([]types.LetterWeight, 0, len(states))
+	for _, state := range states {
+		letter, _ := utf8.DecodeRuneInString(state.Letter)
+		weights = append(weights, types.LetterWeight{Letter: letter, Weight: state.Weight})
+	}
+	return weights
+}
+
+// Load returns the save at path, or nil if there isn't one.
+func Load(path string) (*SaveGame, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var save SaveGame
+	if err := json.Unmarshal(data, &save); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if save.Version != FormatVersion {
+		return nil, fmt.Errorf("%s: unsupported save version %d", path, save.Version)
+	}
+	return &save, nil
+}
+
+func (s SaveGame) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+func Remove(path string) error {
+	err := os.Remove(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,94 @@
+package savegame
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/maverickkamal/Packet-Rush/internal/levels"
+	"github.com/maverickkamal/Packet-Rush/internal/types"
+)
+
+func midGame(t *testing.T) *types.Simulation {
+	t.Helper()
+	sim := levels.NewSimulation(levels.GetLevelData(4), 4, 9)
+	m := types.NewGameModel(sim)
+	for i := 0; i < 30; i++ {
+		sim.Step(m.AutopilotInputs())
+	}
+	if len(sim.Packets) == 0 || sim.Finished() {
+		t.Fatal("no game in progress to save")
+	}
+	return sim
+}
+
+func TestCaptureRestoreRoundTrip(t *testing.T) {
+	sim := midGame(t)
+	save := Capture(sim, 15)
+
+	restored, err := save.Restore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !restored.Paused {
+		t.Error("a restored game should come back paused")
+	}
+	again := Capture(restored, 15)
+	if !reflect.DeepEqual(save, again) {
+		t.Errorf("capturing a restored game changed it:\n%+v\n%+v", save, again)
+	}
+}
+
+func TestRestoredGamePlaysOnTheSame(t *testing.T) {
+	sim := midGame(t)
+	restored, err := Capture(sim, 0).Restore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	restored.Paused = false
+
+	original, resumed := types.NewGameModel(sim), types.NewGameModel(restored)
+	for i := 0; i < 200 && !sim.Finished(); i++ {
+		sim.Step(original.AutopilotInputs())
+		restored.Step(resumed.AutopilotInputs())
+	}
+	if !sim.Finished() {
+		t.Fatal("the game never finished")
+	}
+	if !reflect.DeepEqual(Capture(sim, 0), Capture(restored, 0)) {
+		t.Error("the restored game played out differently from the original")
+	}
+}
+
+func TestSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "savegame.json")
+	save := Capture(midGame(t), 0)
+	if err := save.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(*loaded, save) {
+		t.Error("the save changed on its way through the file")
+	}
+
+	if err := Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	if loaded, err := Load(path); loaded != nil || err != nil {
+		t.Errorf("Load after Remove = %v, %v; want nothing", loaded, err)
+	}
+}
+
+func TestPuzzleQueueKeepsItsSpawners(t *testing.T) {
+	sim := levels.NewPuzzleSimulation(5, 1)
+	restored, err := Capture(sim, 0).Restore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(restored.Queue, sim.Queue) {
+		t.Errorf("queue = %+v, want %+v", restored.Queue, sim.Queue)
+	}
+}
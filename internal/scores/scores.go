@@ -0,0 +1,164 @@
+package scores
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+const (
+	MaxEntries = 10
+
+	BoardCampaign   = "campaign"
+	BoardSurvival   = "survival"
+	BoardTimeAttack = "timeattack"
+)
+
+// Boards lists the leaderboards in the order the scoreboard pages through
+// them.
+var Boards = []string{BoardCampaign, BoardSurvival, BoardTimeAttack}
+
+type Entry struct {
+	Name  string    `json:"name"`
+	Score int       `json:"score"`
+	Level int       `json:"level"`
+	Words int       `json:"words,omitempty"`
+	Date  time.Time `json:"date"`
+}
+
+// LevelBest is the best single run of a level: the points earned in it, the
+// ticks it took and the lives left at the end.
+type LevelBest struct {
+	Score int `json:"score"`
+	Ticks int `json:"ticks"`
+	Lives int `json:"lives"`
+}
+
+// Beats ranks by score, then fewer ticks, then more lives left.
+func (b LevelBest) Beats(other LevelBest) bool {
+	if b.Score != other.Score {
+		return b.Score > other.Score
+	}
+	if b.Ticks != other.Ticks {
+		return b.Ticks < other.Ticks
+	}
+	return b.Lives > other.Lives
+}
+
+type Table struct {
+	Boards     map[string][]Entry `json:"boards"`
+	LevelBests map[int]LevelBest  `json:"level_bests"`
+
+	// PuzzleBests is the fewest switches each puzzle has been solved with.
+	PuzzleBests map[int]int `json:"puzzle_bests,omitempty"`
+
+	path string
+}
+
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "packet-rush", "scores.json"), nil
+}
+
+// Load reads the table at path. A missing file is an empty table.
+func Load(path string) (*Table, error) {
+	t := &Table{
+		Boards:      make(map[string][]Entry),
+		LevelBests:  make(map[int]LevelBest),
+		PuzzleBests: make(map[int]int),
+		path:        path,
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return t, nil
+	}
+	if err != nil {
+		return t, err
+	}
+	if err := json.Unmarshal(data, t); err != nil {
+		return t, err
+	}
+
+	if t.Boards == nil {
+		t.Boards = make(map[string][]Entry)
+	}
+	if t.LevelBests == nil {
+		t.LevelBests = make(map[int]LevelBest)
+	}
+	if t.PuzzleBests == nil {
+		t.PuzzleBests = make(map[int]int)
+	}
+	return t, nil
+}
+
+func (t *Table) Save() error {
+	if err := os.MkdirAll(filepath.Dir(t.path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(t, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(t.path, data, 0o644)
+}
+
+func (t *Table) Board(name string) []Entry {
+	return t.Boards[name]
+}
+
+// Qualifies reports whether score would make it onto the board.
+func (t *Table) Qualifies(board string, score int) bool {
+	if score <= 0 {
+		return false
+	}
+	entries := t.Boards[board]
+	return len(entries) < MaxEntries || score > entries[len(entries)-1].Score
+}
+
+// Add inserts the entry and returns its 0-based rank, or -1 if it didn't
+// make the board.
+func (t *Table) Add(board string, e Entry) int {
+	entries := append(t.Boards[board], e)
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Score > entries[j].Score
+	})
+	if len(entries) > MaxEntries {
+		entries = entries[:MaxEntries]
+	}
+	t.Boards[board] = entries
+
+	for i := range entries {
+		if entries[i] == e {
+			return i
+		}
+	}
+	return -1
+}
+
+// RecordLevel keeps best if it beats the stored result for level and reports
+// whether it did.
+func (t *Table) RecordLevel(level int, best LevelBest) bool {
+	if current, exists := t.LevelBests[level]; exists && !best.Beats(current) {
+		return false
+	}
+	t.LevelBests[level] = best
+	return true
+}
+
+// RecordPuzzle keeps switches if puzzle has not been solved with fewer and
+// reports whether it was kept.
+func (t *Table) RecordPuzzle(puzzle, switches int) bool {
+	if current, exists := t.PuzzleBests[puzzle]; exists && switches >= current {
+		return false
+	}
+	t.PuzzleBests[puzzle] = switches
+	return true
+}
@@ -0,0 +1,26 @@
+package types
+
+import "time"
+
+// Clock is the simulation's source of time. Each Step advances it by the
+// tick it just played, so a ManualClock makes runs reproducible.
+type Clock interface {
+	Now() time.Time
+	Advance(d time.Duration)
+}
+
+type ManualClock struct {
+	now time.Time
+}
+
+func NewManualClock(start time.Time) *ManualClock {
+	return &ManualClock{now: start}
+}
+
+func (c *ManualClock) Now() time.Time {
+	return c.now
+}
+
+func (c *ManualClock) Advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
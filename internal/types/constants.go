@@ -32,8 +32,29 @@ const (
 
 const (
 	CorrectLetterPoints = 20
+	DecoyPoints         = 5
 	LevelCompleteBonus  = 100
+	HintPenalty         = 5
+	ExpiredPenalty      = 10
 	LivesPerLevel       = 2
 	MaxLevel            = 10
+	ExpiryFlashTicks    = 3
+)
+
+const (
+	SurvivalLives            = 3
+	MaxStreakMultiplier      = 8
+	SurvivalWaveSpeedup      = 150 * time.Millisecond
+	SurvivalTickSpeedup      = 5 * time.Millisecond
+	SurvivalMinTickSpeed     = 60 * time.Millisecond
+	SurvivalMinSpawnInterval = 300 * time.Millisecond
+)
+
+const (
+	TimeAttackBase      = 45 * time.Second
+	TimeAttackPerLetter = 10 * time.Second
+	TimeAttackBonus     = 3 * time.Second
+	TimeAttackPenalty   = 10 * time.Second
+	TimeLeftPoints      = 2
 )
 
@@ -0,0 +1,111 @@
+package types
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// hintAlphabet orders label characters home row first, as easymotion does.
+const hintAlphabet = "asdfghjklqwertyuiopzxcvbnm"
+
+// moveCursor selects the nearest switchable junction in dir from the current
+// one, weighting sideways distance double so the cursor keeps to its row or
+// column.
+func (m *GameModel) moveCursor(dir Position) {
+	junctions := m.SwitchableJunctions()
+	if len(junctions) == 0 {
+		return
+	}
+	if m.cursor == nil {
+		m.cursor = junctions[0]
+		return
+	}
+
+	var best *Junction
+	bestScore := 0
+	for _, j := range junctions {
+		along := (j.X-m.cursor.X)*dir.X + (j.Y-m.cursor.Y)*dir.Y
+		if along <= 0 {
+			continue
+		}
+		across := (j.X-m.cursor.X)*dir.Y + (j.Y-m.cursor.Y)*dir.X
+		if across < 0 {
+			across = -across
+		}
+		if score := along + 2*across; best == nil || score < bestScore {
+			best, bestScore = j, score
+		}
+	}
+
+	if best != nil {
+		m.cursor = best
+	}
+}
+
+// hintLabels gives every switchable junction a label in reading order:
+// single letters when they suffice, otherwise two-letter combinations.
+func (m *GameModel) hintLabels() map[string]*Junction {
+	junctions := m.SwitchableJunctions()
+	labels := make(map[string]*Junction, len(junctions))
+
+	n := len(hintAlphabet)
+	for i, j := range junctions {
+		if len(junctions) <= n {
+			labels[hintAlphabet[i:i+1]] = j
+		} else if i < n*n {
+			labels[string([]byte{hintAlphabet[i/n], hintAlphabet[i%n]})] = j
+		}
+	}
+	return labels
+}
+
+func (m *GameModel) startHints() {
+	labels := m.hintLabels()
+	if len(labels) == 0 {
+		return
+	}
+	m.hints = labels
+	m.hintTyped = ""
+}
+
+func (m *GameModel) handleHintKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyCtrlC:
+		return m, tea.Quit
+	case tea.KeyRunes:
+		m.hintTyped += string(msg.Runes)
+	default:
+		m.hints = nil
+		return m, nil
+	}
+
+	if junction, exists := m.hints[m.hintTyped]; exists {
+		m.cursor = junction
+		m.hints = nil
+		return m, nil
+	}
+
+	for label := range m.hints {
+		if strings.HasPrefix(label, m.hintTyped) {
+			return m, nil
+		}
+	}
+	m.hints = nil
+	return m, nil
+}
+
+// hintCells returns the label characters still worth showing, keyed by the
+// grid cells they are drawn over.
+func (m *GameModel) hintCells() map[Position]rune {
+	cells := make(map[Position]rune)
+	for label, j := range m.hints {
+		if !strings.HasPrefix(label, m.hintTyped) {
+			continue
+		}
+		for i, char := range label[len(m.hintTyped):] {
+			cells[Position{X: j.X + i, Y: j.Y}] = char
+		}
+	}
+	return cells
+}
@@ -2,30 +2,74 @@ package types
 
 import (
 	"fmt"
-	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 )
 
 func (m *GameModel) handleKeyInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.hints != nil {
+		return m.handleHintKey(msg)
+	}
+
 	key := msg.String()
 
 	switch key {
 	case "ctrl+c", "q":
 		return m, tea.Quit
 
+	case "up", "k":
+		m.moveCursor(Up)
+		return m, nil
+
+	case "down", "j":
+		m.moveCursor(Down)
+		return m, nil
+
+	case "left", "h":
+		m.moveCursor(Left)
+		return m, nil
+
+	case "right", "l":
+		m.moveCursor(Right)
+		return m, nil
+
+	case "enter":
+		if m.tutorialContinue() {
+			return m, nil
+		}
+		if m.cursor != nil {
+			m.switchTo(m.cursor)
+		}
+		return m, nil
+
+	case "f":
+		m.startHints()
+		return m, nil
+
+	case "a":
+		// The autopilot would switch junctions behind the tutorial's back.
+		if m.tutorial == nil {
+			m.Autopilot = !m.Autopilot
+		}
+		return m, nil
+
+	case "v":
+		m.preview = !m.preview
+		return m, nil
+
+	case "?":
+		m.toggleRouteHint()
+		return m, nil
+
+	case ".":
+		m.stepPuzzle()
+		return m, nil
+
 	case " ":
-		m.Paused = !m.Paused
-		if !m.Paused && !m.GameOver && !m.LevelComplete {
-			return m, tea.Batch(
-				tea.Tick(m.TickSpeed, func(t time.Time) tea.Msg {
-					return TickMsg{Time: t}
-				}),
-				tea.Tick(m.SpawnInterval, func(t time.Time) tea.Msg {
-					return SpawnMsg{Time: t}
-				}),
-			)
+		if m.tutorialContinue() {
+			return m, nil
 		}
+		m.Apply(Input{Kind: InputPause})
 		return m, nil
 
 	case "r":
@@ -42,7 +86,7 @@ func (m *GameModel) handleKeyInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	if len(key) == 1 {
 		keyRune := rune(key[0])
-		if keyRune >= '1' && keyRune <= '9' {
+		if IsJunctionKey(keyRune) {
 			m.switchJunction(keyRune)
 		}
 	}
@@ -51,147 +95,86 @@ func (m *GameModel) handleKeyInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 }
 
 func (m *GameModel) handleGameTick(_ TickMsg) (tea.Model, tea.Cmd) {
-	// Don't process ticks if paused, game over, or level complete
-	if m.Paused || m.GameOver || m.LevelComplete {
+	// The tick loop ends with the level; the coordinator starts a new one.
+	if m.Finished() {
 		return m, nil
 	}
 
-	m.movePackets()
-
-	m.processPacketCollisions()
-
-	m.cleanupPackets()
-
-	m.GameTime++
-
-	// Check game over conditions (after all processing)
-	m.checkGameOver()
-
-	m.TickSpeed = InitialTickSpeed - time.Duration(m.GameTime)*time.Millisecond
-	if m.TickSpeed < MinTickSpeed {
-		m.TickSpeed = MinTickSpeed
+	// Keep ticking while paused so resuming never starts a second loop;
+	// Step ignores ticks until the game is unpaused. Nothing moves while the
+	// terminal is too small to show the board, or while the tutorial waits
+	// for the player.
+	if !m.tooSmall() && !m.tutorialWaiting() {
+		var inputs []Input
+		if m.Autopilot && !m.Paused {
+			inputs = m.AutopilotInputs()
+			m.AutopilotUsed = true
+		}
+		m.Step(inputs)
+		m.tutorialTicked()
 	}
 
-	// Dynamic tick speed - starts slow, gets faster
-	if !m.GameOver && !m.LevelComplete {
-		return m, tea.Tick(m.TickSpeed, func(t time.Time) tea.Msg {
-			return TickMsg{Time: t}
-		})
+	if !m.Finished() {
+		return m, m.tick()
 	}
 
 	return m, nil
 }
 
-func (m *GameModel) handlePacketSpawn(_ SpawnMsg) (tea.Model, tea.Cmd) {
-	if m.Paused || m.GameOver || m.LevelComplete {
-		return m, nil
-	}
+func (m *GameModel) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	junction := m.junctionAtScreen(msg.X, msg.Y)
+	m.hovered = junction
 
-	if len(m.Packets) >= 10 {
-		return m, tea.Tick(m.SpawnInterval, func(t time.Time) tea.Msg {
-			return SpawnMsg{Time: t}
-		})
+	if junction != nil && msg.Action == tea.MouseActionPress && msg.Button == tea.MouseButtonLeft {
+		m.switchTo(junction)
 	}
 
-	if time.Since(m.LastSpawn) > m.SpawnInterval {
-		newPacket := NewRandomPacket(1, 4, m.TargetWord)
-		m.Packets = append(m.Packets, newPacket)
-		m.LastSpawn = time.Now()
+	return m, nil
+}
 
-		if m.SpawnInterval > MinSpawnInterval {
-			m.SpawnInterval -= 50 * time.Millisecond
+// junctionAtScreen maps a terminal cell back to the grid cell drawn there,
+// allowing for wide characters such as emoji earlier in the row.
+func (m *GameModel) junctionAtScreen(col, row int) *Junction {
+	v := m.view
+	y := row - v.top - gridTop + v.y
+	col -= v.left
+	if y < v.y || y >= v.y+v.rows || y >= len(m.Grid) || col < 0 {
+		return nil
+	}
+
+	width := 0
+	chars := []rune(m.Grid[y])
+	for x := v.x; x < len(chars); x++ {
+		width += runeWidth(chars[x])
+		if col < width {
+			return m.Junctions[fmt.Sprintf("%d,%d", x, y)]
 		}
 	}
+	return nil
+}
 
-	if !m.GameOver && !m.LevelComplete {
-		return m, tea.Tick(m.SpawnInterval, func(t time.Time) tea.Msg {
-			return SpawnMsg{Time: t}
-		})
+func runeWidth(char rune) int {
+	if char >= 0x1F000 {
+		return 2
 	}
-
-	return m, nil
+	return 1
 }
 
 func (m *GameModel) switchJunction(key rune) {
 	for _, junction := range m.Junctions {
 		if junction.ID == key {
-			junction.SwitchRoute()
+			m.switchTo(junction)
 			break
 		}
 	}
 }
 
-func (m *GameModel) movePackets() {
-	for _, packet := range m.Packets {
-		oldX, oldY := packet.X, packet.Y
-
-		packet.Move()
-
-		if packet.X != oldX || packet.Y != oldY {
-			m.processPacketAtPosition(packet)
-		}
-	}
-}
-
-func (m *GameModel) processPacketAtPosition(packet *Packet) {
-	char := m.GetCharAt(packet.X, packet.Y)
-
-	jKey := fmt.Sprintf("%d,%d", packet.X, packet.Y)
-	if junction, exists := m.Junctions[jKey]; exists {
-		dir := junction.GetActiveDirection()
-		packet.SetDirection(dir.X, dir.Y)
-	}
-
-	if char == '#' {
-		packet.SetDirection(0, 0) // Stop the packet
-	}
-}
-
-func (m *GameModel) processPacketCollisions() {
-	for i := len(m.Packets) - 1; i >= 0; i-- {
-		packet := m.Packets[i]
-		char := m.GetCharAt(packet.X, packet.Y)
-
-		if char == packet.PacketType {
-			m.Score += CorrectLetterPoints
-			m.GoalProgress = append(m.GoalProgress, packet.PacketType)
-
-			if len(m.GoalProgress) >= len(m.TargetWord) {
-				m.LevelComplete = true
-				m.Score += LevelCompleteBonus
-				return
-			}
-
-			m.removePacket(i)
-			continue
-		} else if IsLetterDestination(char) && char != packet.PacketType {
-			m.Lives--
-			m.removePacket(i)
-			continue
-		}
-	}
-}
-
-func (m *GameModel) cleanupPackets() {
-	for i := len(m.Packets) - 1; i >= 0; i-- {
-		packet := m.Packets[i]
-
-		if !m.IsValidPosition(packet.X, packet.Y) || m.GetCharAt(packet.X, packet.Y) == '#' {
-			m.removePacket(i)
-			m.Lives--
-		}
-	}
-}
-
-func (m *GameModel) removePacket(index int) {
-	if index >= 0 && index < len(m.Packets) {
-		m.Packets[index] = m.Packets[len(m.Packets)-1]
-		m.Packets = m.Packets[:len(m.Packets)-1]
-	}
-}
-
-func (m *GameModel) checkGameOver() {
-	if m.Lives <= 0 && !m.LevelComplete {
-		m.GameOver = true
+// switchTo is how every player switch is made, so a tutorial can hold back
+// the ones its script doesn't ask for.
+func (m *GameModel) switchTo(junction *Junction) {
+	if !m.tutorialAllows(junction) {
+		return
 	}
+	m.Apply(SwitchInput(junction))
+	m.tutorialSwitched(junction)
 }
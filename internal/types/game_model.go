@@ -6,56 +6,61 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 )
 
+// GameModel adapts a Simulation to Bubble Tea: it turns key presses into
+// inputs and drives Step from a timer running at the simulation's tick speed.
 type GameModel struct {
-	Grid      []string
-	Packets   []*Packet
-	Junctions map[string]*Junction
-
-	Score         int
-	Lives         int
-	Level         int
-	GameTime      int
-	LastSpawn     time.Time
-	Paused        bool
-	GameOver      bool
-	LevelComplete bool
-
-	SpawnInterval time.Duration
-	TickSpeed     time.Duration
-
-	CurrentGoal  string
-	GoalProgress []rune
-	TargetWord   string
+	*Simulation
 
 	RestartRequested   bool
 	NextLevelRequested bool
+
+	// Autopilot lets the solver switch junctions each tick. AutopilotUsed
+	// sticks once it has, so the level doesn't count for records.
+	Autopilot     bool
+	AutopilotUsed bool
+
+	// hovered is the junction under the mouse cursor, if any.
+	hovered *Junction
+
+	// cursor is the junction selected with the arrow keys; hints holds the
+	// jump labels while the player is typing one.
+	cursor    *Junction
+	hints     map[string]*Junction
+	hintTyped string
+
+	// routeHint is the packet whose route is being shown; onCourseUntil
+	// keeps the "no hint needed" note up for a few ticks.
+	routeHint     *Packet
+	onCourseUntil int
+
+	// preview traces where every packet ends up under the current switches.
+	preview bool
+
+	// tutorial, when set, is the scripted lesson being played.
+	tutorial *tutorial
+
+	// width and height are the terminal size; view is the part of the grid
+	// drawn last, which mouse clicks are mapped through.
+	width, height int
+	view          viewport
 }
 
 type TickMsg struct {
 	Time time.Time
 }
 
-type SpawnMsg struct {
-	Time time.Time
+func NewGameModel(sim *Simulation) *GameModel {
+	return &GameModel{Simulation: sim}
 }
 
-func NewGameModel() *GameModel {
-	return &GameModel{
-		Level:     1,
-		Lives:     LivesPerLevel,
-		TickSpeed: InitialTickSpeed,
-	}
+func (m *GameModel) Init() tea.Cmd {
+	return m.tick()
 }
 
-func (m *GameModel) Init() tea.Cmd {
-	return tea.Batch(
-		tea.Tick(m.TickSpeed, func(t time.Time) tea.Msg {
-			return TickMsg{Time: t}
-		}),
-		tea.Tick(m.SpawnInterval, func(t time.Time) tea.Msg {
-			return SpawnMsg{Time: t}
-		}),
-	)
+func (m *GameModel) tick() tea.Cmd {
+	return tea.Tick(m.TickSpeed, func(t time.Time) tea.Msg {
+		return TickMsg{Time: t}
+	})
 }
 
 func (m *GameModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -66,23 +71,13 @@ func (m *GameModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case TickMsg:
 		return m.handleGameTick(msg)
 
-	case SpawnMsg:
-		return m.handlePacketSpawn(msg)
+	case tea.MouseMsg:
+		return m.handleMouse(msg)
 
 	case tea.WindowSizeMsg:
+		m.SetSize(msg.Width, msg.Height)
 		return m, nil
 	}
 
 	return m, nil
 }
-
-func (m *GameModel) IsValidPosition(x, y int) bool {
-	return y >= 0 && y < len(m.Grid) && x >= 0 && x < len(m.Grid[y])
-}
-
-func (m *GameModel) GetCharAt(x, y int) rune {
-	if !m.IsValidPosition(x, y) {
-		return '#'
-	}
-	return rune(m.Grid[y][x])
-}
@@ -19,6 +19,25 @@ func NewJunction(x, y int, directions []Position, id rune) *Junction {
 	}
 }
 
+// JunctionKey returns the key for the index-th switchable junction of a
+// level, or 0 past the ninth: the other keys are all bound to commands, so
+// the rest are switched with the mouse or the cursor.
+func JunctionKey(index int) rune {
+	if index < 9 {
+		return rune('1' + index)
+	}
+	return 0
+}
+
+// IsJunctionKey reports whether key switches a junction.
+func IsJunctionKey(key rune) bool {
+	return key >= '1' && key <= '9'
+}
+
+func (j *Junction) IsSwitchable() bool {
+	return len(j.Directions) > 1
+}
+
 func (j *Junction) SwitchRoute() {
 	if len(j.Directions) > 0 {
 		j.ActiveDir = (j.ActiveDir + 1) % len(j.Directions)
@@ -0,0 +1,13 @@
+package types
+
+// Mode is the kind of game a Simulation is playing.
+type Mode string
+
+const (
+	ModeCampaign   Mode = ""
+	ModeSurvival   Mode = "survival"
+	ModeTimeAttack Mode = "timeattack"
+	ModeDaily      Mode = "daily"
+	ModePuzzle     Mode = "puzzle"
+	ModeTutorial   Mode = "tutorial"
+)
@@ -1,12 +1,18 @@
 package types
 
-import "math/rand"
-
 type Packet struct {
 	X, Y       int
 	PacketType rune 
 	Dest       int  
 	DirX, DirY int  
+
+	// Decoy is set on packets whose letter wasn't in the word when they
+	// were spawned; they belong in the sink.
+	Decoy bool
+
+	// TTL is how many more moves, or junctions on levels that count hops,
+	// the packet may make before it is dropped.
+	TTL int
 }
 
 
@@ -21,17 +27,6 @@ func NewPacket(x, y int, packetType rune) *Packet {
 	}
 }
 
-func NewRandomPacket(x, y int, targetWord string) *Packet {
-	if len(targetWord) == 0 {
-		return NewPacket(x, y, 'X')
-	}
-
-	targetRunes := []rune(targetWord)
-	randomType := targetRunes[rand.Intn(len(targetRunes))]
-
-	return NewPacket(x, y, randomType)
-}
-
 func (p *Packet) Move() {
 	p.X += p.DirX
 	p.Y += p.DirY
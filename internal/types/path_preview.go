@@ -0,0 +1,39 @@
+package types
+
+// previewCells traces every packet under the current switches. A cell is
+// true if the packets crossing it are all delivered, false if any of them
+// is headed for the wrong letter, a wall or off the grid.
+func (m *GameModel) previewCells() map[Position]bool {
+	if !m.preview {
+		return nil
+	}
+
+	cells := make(map[Position]bool)
+	for _, p := range m.Packets {
+		path, delivered := m.TracePath(p)
+		for _, cell := range path {
+			if ok, seen := cells[cell]; !seen || ok {
+				cells[cell] = delivered
+			}
+		}
+	}
+	return cells
+}
+
+// previewChar draws a traced track or wall cell in the color of where its
+// packets end up. Open ground a packet would wander across is dotted so the
+// trace stays visible off the track. Junctions, packets and letters keep
+// their own colors.
+func previewChar(char rune, delivered bool) (string, bool) {
+	switch char {
+	case ' ':
+		char = '·'
+	case '-', '|', '#':
+	default:
+		return "", false
+	}
+	if delivered {
+		return ColorGreen + ColorBright + string(char) + ColorReset, true
+	}
+	return ColorRed + ColorBright + string(char) + ColorReset, true
+}
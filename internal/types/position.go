@@ -25,3 +25,31 @@ var (
 	Right = Position{X: 1, Y: 0}
 )
 
+
+func ParseDirection(name string) (Position, bool) {
+	switch name {
+	case "up":
+		return Up, true
+	case "down":
+		return Down, true
+	case "left":
+		return Left, true
+	case "right":
+		return Right, true
+	}
+	return Position{}, false
+}
+
+func DirectionName(dir Position) string {
+	switch dir {
+	case Up:
+		return "up"
+	case Down:
+		return "down"
+	case Left:
+		return "left"
+	case Right:
+		return "right"
+	}
+	return ""
+}
@@ -0,0 +1,93 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// QueuedPacket is a packet a puzzle spawns on a set tick, from the spawner
+// numbered Spawner in reading order.
+type QueuedPacket struct {
+	Letter  rune
+	Tick    int
+	Spawner int
+}
+
+// StartPuzzle turns s into a puzzle: packets come from queue instead of the
+// spawn timer, nothing moves until the player runs or steps the level, and
+// a single lost packet fails it. The fewer switches used the better.
+func (s *Simulation) StartPuzzle(queue []QueuedPacket, par int) {
+	s.Mode = ModePuzzle
+	s.Queue = queue
+	s.Par = par
+	s.Lives = 1
+	s.Paused = true
+}
+
+// spawnQueued sends the queued packets that are due, each from its spawner.
+func (s *Simulation) spawnQueued() {
+	for len(s.Queue) > 0 && s.Queue[0].Tick <= s.GameTime {
+		queued := s.Queue[0]
+		if queued.Spawner < len(s.Spawners) {
+			s.spawnFrom(s.Spawners[queued.Spawner], queued.Letter)
+		}
+		s.Queue = s.Queue[1:]
+	}
+}
+
+// PuzzleStarted reports whether a puzzle has been run. Its junctions are
+// set once, before it runs, and stay that way until it is restarted.
+func (s *Simulation) PuzzleStarted() bool {
+	return s.Mode == ModePuzzle && (!s.Paused || s.GameTime > 0)
+}
+
+// OutOfPackets reports whether a puzzle has spawned and lost all its
+// packets without spelling the word.
+func (s *Simulation) OutOfPackets() bool {
+	return s.Mode == ModePuzzle && len(s.Queue) == 0 && len(s.Packets) == 0
+}
+
+// PuzzleStars rates a solved puzzle: three stars at or under par, two for a
+// couple of switches over, one otherwise.
+func PuzzleStars(switches, par int) int {
+	switch {
+	case switches <= par:
+		return 3
+	case switches <= par+2:
+		return 2
+	}
+	return 1
+}
+
+// stepPuzzle plays a single tick of a stopped puzzle. It runs the puzzle
+// and stops it again through Apply so a replay sees the same pauses.
+func (m *GameModel) stepPuzzle() {
+	if m.Mode != ModePuzzle || !m.Paused || m.Finished() {
+		return
+	}
+	m.Step([]Input{{Kind: InputPause}})
+	if !m.Finished() {
+		m.Apply(Input{Kind: InputPause})
+	}
+}
+
+func stars(n int) string {
+	return strings.Repeat("★", n) + strings.Repeat("☆", 3-n)
+}
+
+// renderQueue lists the packets a puzzle has still to spawn and when.
+func (m *GameModel) renderQueue() string {
+	if len(m.Queue) == 0 {
+		return "│ " + ColorWhite + "Queue empty" + ColorReset
+	}
+	var builder strings.Builder
+	builder.WriteString("│ " + ColorYellow + "Queue: " + ColorReset)
+	for i, queued := range m.Queue {
+		if i == 5 {
+			builder.WriteString(fmt.Sprintf(ColorWhite+"+%d more"+ColorReset, len(m.Queue)-5))
+			break
+		}
+		builder.WriteString(fmt.Sprintf(ColorBright+"%c"+ColorReset+"@%d ", queued.Letter, queued.Tick))
+	}
+	return builder.String()
+}
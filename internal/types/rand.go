@@ -0,0 +1,38 @@
+package types
+
+import "math/rand"
+
+// Rand is the simulation's random number generator. It remembers its seed
+// and how many values it has produced, so it can be saved and restored to
+// exactly the same point in its sequence.
+type Rand struct {
+	*rand.Rand
+	src *countingSource
+}
+
+type countingSource struct {
+	rand.Source
+	seed  int64
+	draws uint64
+}
+
+func (s *countingSource) Int63() int64 {
+	s.draws++
+	return s.Source.Int63()
+}
+
+func NewRand(seed int64) *Rand {
+	return RestoreRand(seed, 0)
+}
+
+func RestoreRand(seed int64, draws uint64) *Rand {
+	src := &countingSource{Source: rand.NewSource(seed), seed: seed}
+	for src.draws < draws {
+		src.Int63()
+	}
+	return &Rand{Rand: rand.New(src), src: src}
+}
+
+func (r *Rand) State() (seed int64, draws uint64) {
+	return r.src.seed, r.src.draws
+}
@@ -0,0 +1,177 @@
+package types
+
+import "fmt"
+
+// Turn is a junction setting a route depends on: the packet has to find
+// junction pointing Dir when it arrives Tick ticks from now.
+type Turn struct {
+	Junction *Junction
+	Dir      Position
+	Tick     int
+}
+
+// Route is the way a packet can get to its letter: every cell it enters, in
+// order, and the junction settings it needs along the way.
+type Route struct {
+	Cells []Position
+	Turns []Turn
+}
+
+type routeState struct {
+	x, y, dirX, dirY int
+}
+
+type routeStep struct {
+	prev routeState
+	tick int
+	turn *Turn
+}
+
+// FindRoute searches for the shortest way p can reach a destination of its
+// own letter, or the sink for a decoy, if the junctions are set for it in
+// time, following the same rules as movePackets and processPacketAtPosition.
+func (s *Simulation) FindRoute(p *Packet) (Route, bool) {
+	start := routeState{p.X, p.Y, p.DirX, p.DirY}
+	if p.DirX == 0 && p.DirY == 0 {
+		return Route{}, false
+	}
+
+	steps := map[routeState]routeStep{start: {}}
+	queue := []routeState{start}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		x, y := current.x+current.dirX, current.y+current.dirY
+		if !s.IsValidPosition(x, y) {
+			continue
+		}
+
+		next := []routeState{{x, y, current.dirX, current.dirY}}
+		var turns []*Turn
+		if junction, exists := s.Junctions[fmt.Sprintf("%d,%d", x, y)]; exists {
+			next, turns = nil, nil
+			for _, dir := range junction.Directions {
+				next = append(next, routeState{x, y, dir.X, dir.Y})
+				turns = append(turns, &Turn{Junction: junction, Dir: dir})
+			}
+		}
+
+		char := s.GetCharAt(x, y)
+		if char == '#' {
+			continue
+		}
+
+		for i, state := range next {
+			if _, seen := steps[state]; seen {
+				continue
+			}
+			step := routeStep{prev: current, tick: steps[current].tick + 1}
+			if turns != nil {
+				step.turn = turns[i]
+				step.turn.Tick = step.tick
+			}
+			steps[state] = step
+
+			if char == s.destination(p) {
+				return buildRoute(steps, start, state), true
+			}
+			// Any other port takes the packet, so the route can't go on.
+			if !IsPort(char) {
+				queue = append(queue, state)
+			}
+		}
+	}
+	return Route{}, false
+}
+
+func buildRoute(steps map[routeState]routeStep, start, end routeState) Route {
+	var route Route
+	for state := end; state != start; state = steps[state].prev {
+		route.Cells = append(route.Cells, Position{X: state.x, Y: state.y})
+		if turn := steps[state].turn; turn != nil {
+			route.Turns = append(route.Turns, *turn)
+		}
+	}
+
+	for i, j := 0, len(route.Cells)-1; i < j; i, j = i+1, j-1 {
+		route.Cells[i], route.Cells[j] = route.Cells[j], route.Cells[i]
+	}
+	for i, j := 0, len(route.Turns)-1; i < j; i, j = i+1, j-1 {
+		route.Turns[i], route.Turns[j] = route.Turns[j], route.Turns[i]
+	}
+
+	return route
+}
+
+// switchesTo is how many times j has to be switched to point dir.
+func switchesTo(j *Junction, dir Position) int {
+	for i, d := range j.Directions {
+		if d == dir {
+			return (i - j.ActiveDir + len(j.Directions)) % len(j.Directions)
+		}
+	}
+	return 0
+}
+
+// AutopilotInputs sets every junction a packet is about to enter so the
+// packet heads for its own letter. Junctions are set just in time, since a
+// packet only reads the junction on the tick it arrives; when two packets
+// reach the same junction together the first one in flight wins.
+func (s *Simulation) AutopilotInputs() []Input {
+	var inputs []Input
+	claimed := make(map[*Junction]bool)
+
+	for _, p := range s.Packets {
+		junction, exists := s.Junctions[fmt.Sprintf("%d,%d", p.X+p.DirX, p.Y+p.DirY)]
+		if !exists || claimed[junction] {
+			continue
+		}
+		route, ok := s.FindRoute(p)
+		if !ok || len(route.Turns) == 0 || route.Turns[0].Junction != junction {
+			continue
+		}
+		claimed[junction] = true
+		for n := switchesTo(junction, route.Turns[0].Dir); n > 0; n-- {
+			inputs = append(inputs, SwitchInput(junction))
+		}
+	}
+	return inputs
+}
+
+// TracePath follows p under the junctions as they are set now, returning
+// every cell it will enter until it reaches a letter, a wall or the edge of
+// the grid, and whether that letter is its own. A packet going round in a
+// loop is never delivered.
+func (s *Simulation) TracePath(p *Packet) ([]Position, bool) {
+	var cells []Position
+	seen := make(map[routeState]bool)
+	state := routeState{p.X, p.Y, p.DirX, p.DirY}
+	for state.dirX != 0 || state.dirY != 0 {
+		if seen[state] {
+			return cells, false
+		}
+		seen[state] = true
+
+		x, y := state.x+state.dirX, state.y+state.dirY
+		if !s.IsValidPosition(x, y) {
+			return cells, false
+		}
+		cells = append(cells, Position{X: x, Y: y})
+
+		state = routeState{x, y, state.dirX, state.dirY}
+		if junction, exists := s.Junctions[fmt.Sprintf("%d,%d", x, y)]; exists {
+			dir := junction.GetActiveDirection()
+			state.dirX, state.dirY = dir.X, dir.Y
+		}
+
+		char := s.GetCharAt(x, y)
+		if char == '#' {
+			return cells, false
+		}
+		if IsPort(char) {
+			return cells, char == s.destination(p)
+		}
+	}
+	return cells, false
+}
@@ -0,0 +1,129 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// toggleRouteHint shows the way home for the packet that needs a switch
+// soonest, at a cost of HintPenalty points, or hides the hint again.
+func (m *GameModel) toggleRouteHint() {
+	if m.routeHint != nil {
+		m.routeHint = nil
+		return
+	}
+
+	packet := m.packetNeedingHelp()
+	if packet == nil {
+		// Say so for a few ticks instead of charging for nothing.
+		m.onCourseUntil = m.GameTime + 10
+		return
+	}
+	m.Apply(Input{Kind: InputHint})
+	m.routeHint = packet
+}
+
+// packetNeedingHelp is the packet closest to a junction that is set the
+// wrong way for it, or nil if every packet is already on course.
+func (m *GameModel) packetNeedingHelp() *Packet {
+	var best *Packet
+	bestTick := 0
+	for _, p := range m.Packets {
+		route, ok := m.FindRoute(p)
+		if !ok {
+			continue
+		}
+		for _, turn := range route.Turns {
+			if turn.Junction.GetActiveDirection() != turn.Dir {
+				if best == nil || turn.Tick < bestTick {
+					best, bestTick = p, turn.Tick
+				}
+				break
+			}
+		}
+	}
+	return best
+}
+
+// currentRouteHint recomputes the hinted packet's route from where it is
+// now, dropping the hint once the packet has been delivered or lost.
+func (m *GameModel) currentRouteHint() (Route, bool) {
+	if m.routeHint == nil {
+		return Route{}, false
+	}
+	for _, p := range m.Packets {
+		if p == m.routeHint {
+			route, ok := m.FindRoute(p)
+			if !ok {
+				m.routeHint = nil
+			}
+			return route, ok
+		}
+	}
+	m.routeHint = nil
+	return Route{}, false
+}
+
+// routeHintCells marks the hinted route and the junctions on it that still
+// have to be switched.
+func (m *GameModel) routeHintCells() (path map[Position]bool, wrong map[Position]bool) {
+	route, ok := m.currentRouteHint()
+	if !ok {
+		return nil, nil
+	}
+
+	path = make(map[Position]bool, len(route.Cells))
+	for _, cell := range route.Cells {
+		path[cell] = true
+	}
+	wrong = make(map[Position]bool)
+	for _, turn := range route.Turns {
+		if turn.Junction.GetActiveDirection() != turn.Dir {
+			wrong[Position{X: turn.Junction.X, Y: turn.Junction.Y}] = true
+		}
+	}
+	return path, wrong
+}
+
+func (m *GameModel) renderRouteHint() string {
+	if m.routeHint == nil {
+		if m.GameTime < m.onCourseUntil {
+			return ColorGreen + "💡 Every packet is on course - no hint needed" + ColorReset + "\n"
+		}
+		return ""
+	}
+
+	route, ok := m.currentRouteHint()
+	if !ok {
+		return ""
+	}
+
+	var switches []string
+	for _, turn := range route.Turns {
+		if turn.Junction.GetActiveDirection() == turn.Dir {
+			continue
+		}
+		if turn.Junction.ID != 0 {
+			switches = append(switches, string(turn.Junction.ID))
+		} else {
+			switches = append(switches, fmt.Sprintf("(%d,%d)", turn.Junction.X, turn.Junction.Y))
+		}
+	}
+
+	text := fmt.Sprintf("💡 Hint (-%d): packet %c takes the green path", HintPenalty, m.routeHint.PacketType)
+	if len(switches) > 0 {
+		text += ", switch red junctions " + strings.Join(switches, ", ")
+	} else {
+		text += " and is on course"
+	}
+	return ColorGreen + text + ColorReset + "\n"
+}
+
+func (s *Simulation) packetAt(x, y int) bool {
+	for _, p := range s.Packets {
+		if p.X == x && p.Y == y {
+			return true
+		}
+	}
+	return false
+}
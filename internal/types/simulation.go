@@ -0,0 +1,400 @@
+package types
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Simulation holds the complete game state and rules with no dependency on
+// Bubble Tea, so it can be stepped headlessly by tests, bots and replays.
+type Simulation struct {
+	Grid      []string
+	Packets   []*Packet
+	Junctions map[string]*Junction
+
+	Score         int
+	Lives         int
+	Misroutes     int // packets lost to a wrong letter, a wall or the edge
+	Switches      int // junction switches made
+	Level         int
+	GameTime      int
+	Paused        bool
+	GameOver      bool
+	LevelComplete bool
+
+	// Elapsed is the game time played, the sum of every tick's length, and
+	// TimeLeft what remains of a time attack's clock.
+	Elapsed  time.Duration
+	TimeLeft time.Duration
+
+	// Spawners are where packets come from. SpawnInterval is the level's
+	// interval, shared by the spawners that don't set their own.
+	Spawners      []*Spawner
+	SpawnInterval time.Duration
+
+	// SpawnTable, when set, weights the letters spawned and adds decoys;
+	// RecentLetters is what it has drawn lately, for its window.
+	SpawnTable    *SpawnTable
+	RecentLetters []rune
+
+	// Collisions and LinkCapacity, when set, make packets get in each
+	// other's way: LinkCapacity is how many packets a straight run of track
+	// holds before the next one has to wait to enter it.
+	Collisions   CollisionRule
+	LinkCapacity int
+	links        map[Position]link
+
+	// TTL, when set, is how far each packet may travel before it is
+	// dropped: that many moves, or that many junctions if TTLHops is set.
+	TTL     int
+	TTLHops bool
+	expired []expiry
+
+	TickSpeed time.Duration
+
+	CurrentGoal  string
+	GoalProgress []rune
+	TargetWord   string
+
+	// Ordered makes the word's letters count only when they are delivered in
+	// the order it is spelled.
+	Ordered bool
+
+	// Mode is the kind of game. A survival run spells Words one after
+	// another, keeping count of them and of the current streak.
+	Mode           Mode
+	Words          []string
+	Streak         int
+	WordsCompleted int
+
+	// Queue is what a puzzle or the tutorial spawns instead of random
+	// packets, and Par the fewest switches a puzzle can be solved with.
+	Queue []QueuedPacket
+	Par   int
+
+	Seed  int64
+	Clock Clock
+	Rand  *Rand
+
+	// OnEvent, when set, is told about every switch, pause, hint and spawn.
+	OnEvent func(Event)
+}
+
+type InputKind int
+
+const (
+	InputSwitch InputKind = iota
+	InputPause
+	InputHint
+)
+
+// Input is a player action. Switches name the junction by position so they
+// stay unambiguous when several junctions share a key.
+type Input struct {
+	Kind InputKind
+	X, Y int
+}
+
+type EventKind string
+
+const (
+	EventSwitch EventKind = "switch"
+	EventPause  EventKind = "pause"
+	EventSpawn  EventKind = "spawn"
+	EventHint   EventKind = "hint"
+)
+
+// Event records something that happened on a given tick. Inputs carry the
+// tick they were applied before; spawns carry the tick they happened on.
+type Event struct {
+	Tick   int
+	Kind   EventKind
+	X, Y   int
+	Letter rune
+}
+
+func (s *Simulation) emit(e Event) {
+	if s.OnEvent != nil {
+		e.Tick = s.GameTime
+		s.OnEvent(e)
+	}
+}
+
+func SwitchInput(j *Junction) Input {
+	return Input{Kind: InputSwitch, X: j.X, Y: j.Y}
+}
+
+// SortedJunctions lists the junctions in reading order.
+func (s *Simulation) SortedJunctions() []*Junction {
+	junctions := make([]*Junction, 0, len(s.Junctions))
+	for _, j := range s.Junctions {
+		junctions = append(junctions, j)
+	}
+	sort.Slice(junctions, func(a, b int) bool {
+		if junctions[a].Y != junctions[b].Y {
+			return junctions[a].Y < junctions[b].Y
+		}
+		return junctions[a].X < junctions[b].X
+	})
+	return junctions
+}
+
+// SwitchableJunctions lists, in reading order, the junctions that have more
+// than one way to point.
+func (s *Simulation) SwitchableJunctions() []*Junction {
+	var junctions []*Junction
+	for _, j := range s.SortedJunctions() {
+		if j.IsSwitchable() {
+			junctions = append(junctions, j)
+		}
+	}
+	return junctions
+}
+
+func (s *Simulation) Finished() bool {
+	return s.GameOver || s.LevelComplete
+}
+
+func (s *Simulation) Apply(in Input) {
+	switch in.Kind {
+	case InputSwitch:
+		// Fixed junctions have nowhere else to point, and a switch that
+		// changes nothing isn't counted against a puzzle. A puzzle that
+		// has been run takes no more switches.
+		if s.PuzzleStarted() {
+			return
+		}
+		if junction, exists := s.Junctions[fmt.Sprintf("%d,%d", in.X, in.Y)]; exists {
+			before := junction.ActiveDir
+			junction.SwitchRoute()
+			if junction.ActiveDir != before {
+				s.Switches++
+				s.emit(Event{Kind: EventSwitch, X: in.X, Y: in.Y})
+			}
+		}
+	case InputPause:
+		s.Paused = !s.Paused
+		s.emit(Event{Kind: EventPause})
+	case InputHint:
+		s.Score = max(0, s.Score-HintPenalty)
+		s.emit(Event{Kind: EventHint})
+	}
+}
+
+// Step applies the inputs and then plays one tick: packets move, deliveries
+// and losses are scored, the clock advances and a packet spawns if one is due.
+func (s *Simulation) Step(inputs []Input) {
+	for _, in := range inputs {
+		s.Apply(in)
+	}
+
+	if s.Paused || s.Finished() {
+		return
+	}
+
+	s.movePackets()
+
+	s.processPacketCollisions()
+
+	s.cleanupPackets()
+
+	s.GameTime++
+	s.Elapsed += s.TickSpeed
+	if s.Mode == ModeTimeAttack {
+		s.TimeLeft -= s.TickSpeed
+	}
+
+	// Check game over conditions (after all processing)
+	s.checkGameOver()
+
+	s.Clock.Advance(s.TickSpeed)
+
+	// Dynamic tick speed - starts slow, gets faster
+	s.TickSpeed = InitialTickSpeed - time.Duration(s.GameTime)*time.Millisecond
+	if s.TickSpeed < MinTickSpeed {
+		s.TickSpeed = MinTickSpeed
+	}
+	if s.Mode == ModeSurvival {
+		s.TickSpeed = max(s.TickSpeed-time.Duration(s.WordsCompleted)*SurvivalTickSpeedup, s.minTickSpeed())
+	}
+
+	if !s.Finished() {
+		s.spawnPacket()
+	}
+}
+
+func (s *Simulation) spawnPacket() {
+	if s.Mode == ModePuzzle || s.Mode == ModeTutorial {
+		s.spawnQueued()
+		return
+	}
+
+	// The shared interval speeds up once per tick, however many of the
+	// spawners using it fired.
+	speedUp := false
+	for _, sp := range s.Spawners {
+		if len(s.Packets) >= MaxPackets {
+			break
+		}
+		if !s.due(sp) || s.blocked(sp) {
+			continue
+		}
+		s.spawnFrom(sp, s.randomLetter(sp))
+		speedUp = speedUp || sp.Interval == 0
+	}
+
+	if speedUp && s.SpawnInterval > s.minSpawnInterval() {
+		s.SpawnInterval -= 50 * time.Millisecond
+	}
+}
+
+func (s *Simulation) movePackets() {
+	if s.inTraffic() {
+		s.moveInTraffic()
+		if s.Collisions == CollisionsCrash {
+			s.crashPackets()
+		}
+		return
+	}
+
+	for _, packet := range s.Packets {
+		oldX, oldY := packet.X, packet.Y
+
+		packet.Move()
+
+		if packet.X != oldX || packet.Y != oldY {
+			s.processPacketAtPosition(packet)
+		}
+	}
+}
+
+func (s *Simulation) processPacketAtPosition(packet *Packet) {
+	s.age(packet)
+	char := s.GetCharAt(packet.X, packet.Y)
+
+	jKey := fmt.Sprintf("%d,%d", packet.X, packet.Y)
+	if junction, exists := s.Junctions[jKey]; exists {
+		dir := junction.GetActiveDirection()
+		packet.SetDirection(dir.X, dir.Y)
+	}
+
+	if char == '#' {
+		packet.SetDirection(0, 0) // Stop the packet
+	}
+}
+
+func (s *Simulation) processPacketCollisions() {
+	for i := len(s.Packets) - 1; i >= 0; i-- {
+		packet := s.Packets[i]
+		char := s.GetCharAt(packet.X, packet.Y)
+
+		if s.holding(packet) {
+			continue
+		}
+
+		if char == Sink {
+			if packet.Decoy {
+				s.Score += DecoyPoints * s.Multiplier()
+			}
+			s.removePacket(i)
+			continue
+		}
+
+		if char == packet.PacketType {
+			// A letter the word doesn't need now, extra or out of turn, is
+			// turned away without points; only a decoy costs a life.
+			if !s.Needs(packet.PacketType) {
+				if packet.Decoy {
+					s.loseLife()
+				}
+				s.removePacket(i)
+				continue
+			}
+
+			s.Score += CorrectLetterPoints * s.Multiplier()
+			s.GoalProgress = append(s.GoalProgress, packet.PacketType)
+			if s.Mode == ModeTimeAttack {
+				s.TimeLeft += TimeAttackBonus
+			}
+
+			if len(s.GoalProgress) >= len(s.TargetWord) {
+				if s.Mode != ModeSurvival {
+					s.LevelComplete = true
+					s.Score += LevelCompleteBonus + s.timeLeftBonus()
+					return
+				}
+				s.finishWord()
+			}
+
+			s.removePacket(i)
+			continue
+		} else if IsLetterDestination(char) && char != packet.PacketType {
+			s.loseLife()
+			s.removePacket(i)
+			continue
+		}
+	}
+}
+
+// loseLife is what a lost packet costs: a life, or time in a time attack.
+// Either way it breaks the streak.
+func (s *Simulation) loseLife() {
+	s.Misroutes++
+	s.Streak = 0
+	if s.Mode == ModeTimeAttack {
+		s.TimeLeft -= TimeAttackPenalty
+		return
+	}
+	s.Lives--
+}
+
+func (s *Simulation) cleanupPackets() {
+	s.forgetExpiries()
+	for i := len(s.Packets) - 1; i >= 0; i-- {
+		packet := s.Packets[i]
+
+		if s.Expired(packet) {
+			s.removePacket(i)
+			s.expire(packet)
+			continue
+		}
+
+		// A packet that has stopped, on a junction with no way out, is
+		// lost like one that hit a wall instead of blocking the track.
+		stopped := packet.DirX == 0 && packet.DirY == 0
+		if !s.IsValidPosition(packet.X, packet.Y) || s.GetCharAt(packet.X, packet.Y) == '#' || stopped {
+			s.removePacket(i)
+			s.loseLife()
+		}
+	}
+}
+
+func (s *Simulation) removePacket(index int) {
+	if index >= 0 && index < len(s.Packets) {
+		s.Packets[index] = s.Packets[len(s.Packets)-1]
+		s.Packets = s.Packets[:len(s.Packets)-1]
+	}
+}
+
+func (s *Simulation) checkGameOver() {
+	if (s.Lives <= 0 || s.TimedOut() || s.OutOfPackets()) && !s.LevelComplete {
+		s.GameOver = true
+	}
+}
+
+// TimedOut reports whether a time attack's clock has run out.
+func (s *Simulation) TimedOut() bool {
+	return s.Mode == ModeTimeAttack && s.TimeLeft <= 0
+}
+
+func (s *Simulation) IsValidPosition(x, y int) bool {
+	return y >= 0 && y < len(s.Grid) && x >= 0 && x < len(s.Grid[y])
+}
+
+func (s *Simulation) GetCharAt(x, y int) rune {
+	if !s.IsValidPosition(x, y) {
+		return '#'
+	}
+	return rune(s.Grid[y][x])
+}
@@ -0,0 +1,114 @@
+package types
+
+import "strings"
+
+// Sink is the discard port decoy packets have to be routed to. Anything
+// else sent there is simply dropped.
+const Sink = '*'
+
+type LetterWeight struct {
+	Letter rune
+	Weight int
+}
+
+// SpawnTable is how a level picks the letters of the packets its spawners
+// send. Letters come from the word and Decoys from outside it, each drawn in
+// proportion to its weight. With a Window, every letter the word still needs
+// comes at least once in every Window packets, so a level can't stall on
+// bad luck.
+type SpawnTable struct {
+	Letters []LetterWeight
+	Decoys  []LetterWeight
+	Window  int
+}
+
+// IsDecoy reports whether letter is no part of the word being spelled, so
+// a packet spawned with it belongs in the sink. Packets remember this, since
+// survival changes the word while the last one's packets are still about.
+func (s *Simulation) IsDecoy(letter rune) bool {
+	return !strings.ContainsRune(s.TargetWord, letter)
+}
+
+// destination is the port p has to reach.
+func (s *Simulation) destination(p *Packet) rune {
+	if p.Decoy {
+		return Sink
+	}
+	return p.PacketType
+}
+
+// packetColor shows decoys in red, whatever their letter.
+func (m *GameModel) packetColor(p *Packet) string {
+	if p.Decoy {
+		return ColorRed
+	}
+	return p.GetColor()
+}
+
+// IsPort reports whether char takes in the packets that reach it.
+func IsPort(char rune) bool {
+	return IsLetterDestination(char) || char == Sink
+}
+
+// tableLetter draws a letter from the level's spawn table, or from the word
+// when there isn't one.
+func (s *Simulation) tableLetter() rune {
+	t := s.SpawnTable
+	if t == nil {
+		word := []rune(s.TargetWord)
+		if len(word) == 0 {
+			return 'X'
+		}
+		return word[s.Rand.Intn(len(word))]
+	}
+
+	letter, ok := s.overdueLetter()
+	if !ok {
+		letter = s.weightedLetter()
+	}
+	if t.Window > 1 {
+		s.RecentLetters = append(s.RecentLetters, letter)
+		if len(s.RecentLetters) > t.Window-1 {
+			s.RecentLetters = s.RecentLetters[len(s.RecentLetters)-(t.Window-1):]
+		}
+	}
+	return letter
+}
+
+func (s *Simulation) weightedLetter() rune {
+	t := s.SpawnTable
+	total := 0
+	for _, w := range t.Letters {
+		total += w.Weight
+	}
+	for _, w := range t.Decoys {
+		total += w.Weight
+	}
+	if total <= 0 {
+		return 'X'
+	}
+
+	n := s.Rand.Intn(total)
+	for _, w := range append(append([]LetterWeight{}, t.Letters...), t.Decoys...) {
+		if n < w.Weight {
+			return w.Letter
+		}
+		n -= w.Weight
+	}
+	return 'X'
+}
+
+// overdueLetter is the first letter the word still needs that hasn't come in
+// the last Window-1 packets.
+func (s *Simulation) overdueLetter() (rune, bool) {
+	t := s.SpawnTable
+	if t.Window <= 0 || len(s.RecentLetters) < t.Window-1 {
+		return 0, false
+	}
+	for _, letter := range []rune(s.TargetWord) {
+		if s.Needs(letter) && !strings.ContainsRune(string(s.RecentLetters), letter) {
+			return letter, true
+		}
+	}
+	return 0, false
+}
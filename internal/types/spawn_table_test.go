@@ -0,0 +1,60 @@
+package types
+
+import (
+	"strings"
+	"testing"
+)
+
+func tableSimulation(word string, table *SpawnTable) *Simulation {
+	return &Simulation{TargetWord: word, SpawnTable: table, Rand: NewRand(1)}
+}
+
+func TestSpawnTableDrawsInProportionToWeight(t *testing.T) {
+	s := tableSimulation("AB", &SpawnTable{
+		Letters: []LetterWeight{{Letter: 'A', Weight: 3}, {Letter: 'B', Weight: 1}},
+		Decoys:  []LetterWeight{{Letter: 'Z', Weight: 0}},
+	})
+	counts := make(map[rune]int)
+	for i := 0; i < 4000; i++ {
+		counts[s.tableLetter()]++
+	}
+	if counts['Z'] != 0 {
+		t.Errorf("a decoy weighted 0 was drawn %d times", counts['Z'])
+	}
+	if ratio := float64(counts['A']) / float64(counts['B']); ratio < 2.5 || ratio > 3.5 {
+		t.Errorf("A:B = %d:%d, want about 3:1", counts['A'], counts['B'])
+	}
+}
+
+func TestSpawnTableWindowBringsEveryNeededLetter(t *testing.T) {
+	s := tableSimulation("AB", &SpawnTable{
+		Letters: []LetterWeight{{Letter: 'A', Weight: 1}},
+		Window:  3,
+	})
+	var drawn strings.Builder
+	for i := 0; i < 30; i++ {
+		drawn.WriteRune(s.tableLetter())
+	}
+	letters := drawn.String()
+	for i := 0; i+3 <= len(letters); i++ {
+		if !strings.ContainsRune(letters[i:i+3], 'B') {
+			t.Fatalf("no B in packets %d-%d of %s", i+1, i+3, letters)
+		}
+	}
+
+	// Once B is spelled it is no longer overdue.
+	s.GoalProgress = []rune{'B'}
+	s.RecentLetters = nil
+	for i := 0; i < 10; i++ {
+		if letter := s.tableLetter(); letter != 'A' {
+			t.Fatalf("drew %c after B was spelled", letter)
+		}
+	}
+}
+
+func TestDecoysAreLettersOutsideTheWord(t *testing.T) {
+	s := tableSimulation("CODE", nil)
+	if s.IsDecoy('O') || !s.IsDecoy('X') {
+		t.Error("IsDecoy doesn't go by the word's letters")
+	}
+}
@@ -0,0 +1,56 @@
+package types
+
+import "time"
+
+// MaxPackets is how many packets can be in flight at once, across every
+// spawner.
+const MaxPackets = 10
+
+// Spawner is an S packets enter the network from, heading Dir. Left zero,
+// Interval follows the level's spawn interval as it speeds up, Letters draws
+// from the level's spawn table, and Delay starts the spawner with the level. A
+// Letters string draws each of its letters equally often, so repeating one
+// makes it more common.
+type Spawner struct {
+	X, Y     int
+	Dir      Position
+	Interval time.Duration
+	Letters  string
+	Delay    time.Duration
+
+	// LastSpawn is when the spawner last sent a packet, or the start of the
+	// level.
+	LastSpawn time.Time
+}
+
+func (s *Simulation) spawnerInterval(sp *Spawner) time.Duration {
+	if sp.Interval > 0 {
+		return sp.Interval
+	}
+	return s.SpawnInterval
+}
+
+// due reports whether sp has waited out its delay and its interval.
+func (s *Simulation) due(sp *Spawner) bool {
+	return s.Elapsed >= sp.Delay && s.Clock.Now().Sub(sp.LastSpawn) >= s.spawnerInterval(sp)
+}
+
+func (s *Simulation) spawnFrom(sp *Spawner, letter rune) {
+	p := NewPacket(sp.X, sp.Y, letter)
+	p.SetDirection(sp.Dir.X, sp.Dir.Y)
+	p.TTL = s.TTL
+	p.Decoy = s.IsDecoy(letter)
+	s.Packets = append(s.Packets, p)
+	sp.LastSpawn = s.Clock.Now()
+	s.emit(Event{Kind: EventSpawn, X: p.X, Y: p.Y, Letter: p.PacketType})
+}
+
+// randomLetter draws sp's next letter, from its own letters if it has any
+// and otherwise from the level's spawn table.
+func (s *Simulation) randomLetter(sp *Spawner) rune {
+	if sp.Letters == "" {
+		return s.tableLetter()
+	}
+	letters := []rune(sp.Letters)
+	return letters[s.Rand.Intn(len(letters))]
+}
@@ -0,0 +1,75 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Needs reports whether delivering letter now would count towards the word:
+// it has to be the next letter when spelling in order, or otherwise one the
+// word still has room for, so a repeated letter only counts as often as the
+// word uses it.
+func (s *Simulation) Needs(letter rune) bool {
+	word := []rune(s.TargetWord)
+	if s.Ordered {
+		return len(s.GoalProgress) < len(word) && word[len(s.GoalProgress)] == letter
+	}
+	for _, slot := range s.filledSlots() {
+		if !slot.ok && slot.letter == letter {
+			return true
+		}
+	}
+	return false
+}
+
+type wordSlot struct {
+	letter rune
+	ok     bool
+}
+
+// filledSlots lines the delivered letters up with the word, each one taking
+// the first free position with its letter.
+func (s *Simulation) filledSlots() []wordSlot {
+	word := []rune(s.TargetWord)
+	slots := make([]wordSlot, len(word))
+	for i, letter := range word {
+		slots[i].letter = letter
+	}
+	for _, letter := range s.GoalProgress {
+		for i := range slots {
+			if !slots[i].ok && slots[i].letter == letter {
+				slots[i].ok = true
+				break
+			}
+		}
+	}
+	return slots
+}
+
+// renderProgress shows the word a slot per letter, filled in as letters are
+// delivered, with the letter that has to come next marked when spelling in
+// order.
+func (m *GameModel) renderProgress() string {
+	var builder strings.Builder
+	needed := 0
+	for i, slot := range m.filledSlots() {
+		switch {
+		case slot.ok:
+			builder.WriteString(BgGreen + ColorBlack + ColorBright + string(slot.letter) + ColorReset + " ")
+		case m.Ordered && i == len(m.GoalProgress):
+			builder.WriteString(BgYellow + ColorBlack + ColorBright + string(slot.letter) + ColorReset + " ")
+			needed++
+		default:
+			builder.WriteString(ColorWhite + string(slot.letter) + ColorReset + " ")
+			needed++
+		}
+	}
+	if needed > 0 {
+		text := fmt.Sprintf("(need %d more)", needed)
+		if m.Ordered {
+			text = fmt.Sprintf("(need %d more, in order)", needed)
+		}
+		builder.WriteString(ColorWhite + text + ColorReset)
+	}
+	return builder.String()
+}
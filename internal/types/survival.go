@@ -0,0 +1,63 @@
+package types
+
+import (
+	"fmt"
+	"time"
+)
+
+// StartSurvival turns s into an endless run over words: each finished word
+// is replaced by another one and the run only ends when the lives run out.
+func (s *Simulation) StartSurvival(words []string) {
+	s.Mode = ModeSurvival
+	s.Words = words
+	s.Lives = SurvivalLives
+	s.setWord()
+}
+
+// Multiplier is what letter points and word bonuses are worth right now:
+// one more for every word finished since the last life was lost.
+func (s *Simulation) Multiplier() int {
+	return min(1+s.Streak, MaxStreakMultiplier)
+}
+
+// Wave is the number of the word being spelled in a survival run.
+func (s *Simulation) Wave() int {
+	return s.WordsCompleted + 1
+}
+
+// finishWord scores a completed survival word, moves on to the next one and
+// makes the packets come faster.
+func (s *Simulation) finishWord() {
+	s.Score += LevelCompleteBonus * s.Multiplier()
+	s.WordsCompleted++
+	s.Streak++
+	s.SpawnInterval = max(s.SpawnInterval-SurvivalWaveSpeedup, SurvivalMinSpawnInterval)
+	s.setWord()
+}
+
+func (s *Simulation) setWord() {
+	// Never the same word twice in a row.
+	word := s.Words[s.Rand.Intn(len(s.Words))]
+	for word == s.TargetWord && len(s.Words) > 1 {
+		word = s.Words[s.Rand.Intn(len(s.Words))]
+	}
+	s.TargetWord = word
+	s.GoalProgress = s.GoalProgress[:0]
+	s.CurrentGoal = fmt.Sprintf("Wave %d: spell '%s' - every word in a row raises the multiplier!", s.Wave(), word)
+}
+
+// minTickSpeed and minSpawnInterval are how fast the game gets. A survival
+// run keeps speeding up past the campaign's limits.
+func (s *Simulation) minTickSpeed() time.Duration {
+	if s.Mode == ModeSurvival {
+		return SurvivalMinTickSpeed
+	}
+	return MinTickSpeed
+}
+
+func (s *Simulation) minSpawnInterval() time.Duration {
+	if s.Mode == ModeSurvival {
+		return SurvivalMinSpawnInterval
+	}
+	return MinSpawnInterval
+}
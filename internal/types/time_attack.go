@@ -0,0 +1,35 @@
+package types
+
+import (
+	"fmt"
+	"time"
+)
+
+// StartTimeAttack puts the level against the clock: misroutes cost time
+// instead of lives, deliveries earn some back, and the game is over when
+// the time runs out.
+func (s *Simulation) StartTimeAttack() {
+	s.Mode = ModeTimeAttack
+	s.TimeLeft = TimeAttackLimit(s.TargetWord)
+}
+
+// TimeAttackLimit is how long a time attack gives to spell word.
+func TimeAttackLimit(word string) time.Duration {
+	return TimeAttackBase + time.Duration(len(word))*TimeAttackPerLetter
+}
+
+// timeLeftBonus is what finishing a time attack level with time to spare
+// is worth.
+func (s *Simulation) timeLeftBonus() int {
+	if s.Mode != ModeTimeAttack {
+		return 0
+	}
+	return int(s.TimeLeft.Seconds()) * TimeLeftPoints
+}
+
+// formatClock shows d as minutes and seconds, rounding up so the clock only
+// reads 0:00 once the time is really gone.
+func formatClock(d time.Duration) string {
+	seconds := int((max(d, 0) + time.Second - 1) / time.Second)
+	return fmt.Sprintf("%d:%02d", seconds/60, seconds%60)
+}
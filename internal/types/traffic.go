@@ -0,0 +1,189 @@
+package types
+
+// CollisionRule is what happens when packets meet in the same cell.
+type CollisionRule string
+
+const (
+	// CollisionsOff lets packets pass through each other.
+	CollisionsOff CollisionRule = ""
+	// CollisionsCrash drops packets that end a tick in the same cell, at a
+	// cost of one life for the crash.
+	CollisionsCrash CollisionRule = "crash"
+	// CollisionsWait holds a packet back while the cell ahead is taken, so
+	// packets queue up behind each other.
+	CollisionsWait CollisionRule = "wait"
+)
+
+// ParseCollisionRule reads a rule as it is written in level files.
+func ParseCollisionRule(name string) (CollisionRule, bool) {
+	switch rule := CollisionRule(name); rule {
+	case CollisionsOff, CollisionsCrash, CollisionsWait:
+		return rule, true
+	}
+	return CollisionsOff, false
+}
+
+// link is a straight run of track between junctions and ports, the way a
+// router link joins two routers.
+type link struct {
+	start    Position
+	vertical bool
+}
+
+// linkAt is the link the track at pos belongs to, if it is track at all.
+func (s *Simulation) linkAt(pos Position) (link, bool) {
+	if s.links == nil {
+		s.links = make(map[Position]link)
+		// Cells are bytes, the way GetCharAt and the parser see them.
+		for y, row := range s.Grid {
+			for x := 0; x < len(row); x++ {
+				pos := Position{X: x, Y: y}
+				switch row[x] {
+				case '-':
+					if l, ok := s.links[Position{X: x - 1, Y: y}]; ok && !l.vertical {
+						s.links[pos] = l
+					} else {
+						s.links[pos] = link{start: pos}
+					}
+				case '|':
+					if l, ok := s.links[Position{X: x, Y: y - 1}]; ok && l.vertical {
+						s.links[pos] = l
+					} else {
+						s.links[pos] = link{start: pos, vertical: true}
+					}
+				}
+			}
+		}
+	}
+	l, ok := s.links[pos]
+	return l, ok
+}
+
+// inTraffic reports whether packets get in each other's way at all.
+func (s *Simulation) inTraffic() bool {
+	return s.Collisions != CollisionsOff || s.LinkCapacity > 0
+}
+
+// blocked reports whether sp has to hold its next packet because the last
+// one is still waiting to leave.
+func (s *Simulation) blocked(sp *Spawner) bool {
+	return s.inTraffic() && s.packetAt(sp.X, sp.Y)
+}
+
+// holding reports whether p is waiting on the spawner it came from, which
+// is no port for it even when the S is in the word.
+func (s *Simulation) holding(p *Packet) bool {
+	if !s.inTraffic() {
+		return false
+	}
+	for _, sp := range s.Spawners {
+		if p.X == sp.X && p.Y == sp.Y {
+			return true
+		}
+	}
+	return false
+}
+
+// moveInTraffic moves every packet that can go. A packet waits while the
+// cell ahead is taken under CollisionsWait, or while the link ahead is
+// full. Packets move in passes so one can follow another into the cell it
+// has just left.
+func (s *Simulation) moveInTraffic() {
+	if s.Collisions != CollisionsOff {
+		s.crashHeadOn()
+	}
+
+	occupied := make(map[Position]int)
+	onLink := make(map[link]int)
+	for _, p := range s.Packets {
+		pos := Position{X: p.X, Y: p.Y}
+		occupied[pos]++
+		if l, ok := s.linkAt(pos); ok {
+			onLink[l]++
+		}
+	}
+
+	moved := make([]bool, len(s.Packets))
+	for progress := true; progress; {
+		progress = false
+		for i, p := range s.Packets {
+			if moved[i] || (p.DirX == 0 && p.DirY == 0) {
+				continue
+			}
+			from := Position{X: p.X, Y: p.Y}
+			to := from.Add(Position{X: p.DirX, Y: p.DirY})
+
+			if s.Collisions == CollisionsWait && occupied[to] > 0 {
+				continue
+			}
+			fromLink, onFrom := s.linkAt(from)
+			toLink, onTo := s.linkAt(to)
+			entering := onTo && (!onFrom || fromLink != toLink)
+			if s.LinkCapacity > 0 && entering && onLink[toLink] >= s.LinkCapacity {
+				continue
+			}
+
+			occupied[from]--
+			occupied[to]++
+			if onFrom {
+				onLink[fromLink]--
+			}
+			if onTo {
+				onLink[toLink]++
+			}
+			p.Move()
+			s.processPacketAtPosition(p)
+			moved[i] = true
+			progress = true
+		}
+	}
+}
+
+// crashHeadOn drops packets about to run into each other from opposite
+// directions. They would pass through each other in one tick rather than
+// meet in a cell, and neither could ever wait the other out.
+func (s *Simulation) crashHeadOn() {
+	ahead := make(map[Position]*Packet)
+	for _, p := range s.Packets {
+		ahead[Position{X: p.X, Y: p.Y}] = p
+	}
+
+	crashed := make(map[*Packet]bool)
+	for _, p := range s.Packets {
+		q := ahead[Position{X: p.X + p.DirX, Y: p.Y + p.DirY}]
+		if q == nil || crashed[p] || (p.DirX == 0 && p.DirY == 0) || q.DirX != -p.DirX || q.DirY != -p.DirY {
+			continue
+		}
+		crashed[p], crashed[q] = true, true
+		s.loseLife()
+	}
+
+	for i := len(s.Packets) - 1; i >= 0; i-- {
+		if crashed[s.Packets[i]] {
+			s.removePacket(i)
+		}
+	}
+}
+
+// crashPackets drops every packet that shares a cell with another. Ports
+// take packets in one at a time, so nothing crashes there.
+func (s *Simulation) crashPackets() {
+	count := make(map[Position]int)
+	for _, p := range s.Packets {
+		count[Position{X: p.X, Y: p.Y}]++
+	}
+
+	crashed := make(map[Position]bool)
+	for i := len(s.Packets) - 1; i >= 0; i-- {
+		p := s.Packets[i]
+		pos := Position{X: p.X, Y: p.Y}
+		if count[pos] < 2 || IsPort(s.GetCharAt(p.X, p.Y)) {
+			continue
+		}
+		if !crashed[pos] {
+			crashed[pos] = true
+			s.loseLife()
+		}
+		s.removePacket(i)
+	}
+}
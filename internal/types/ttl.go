@@ -0,0 +1,56 @@
+package types
+
+import "fmt"
+
+// expiry marks where a packet's TTL ran out, for the board to flash it.
+type expiry struct {
+	At   Position
+	Tick int
+}
+
+// age counts a move against p's TTL; on levels that count hops, only a
+// move onto a junction does.
+func (s *Simulation) age(p *Packet) {
+	if s.TTL == 0 {
+		return
+	}
+	if _, onJunction := s.Junctions[fmt.Sprintf("%d,%d", p.X, p.Y)]; s.TTLHops && !onJunction {
+		return
+	}
+	p.TTL--
+}
+
+// Expired reports whether p has used up its TTL. A packet that reaches a
+// port with its last move is still delivered.
+func (s *Simulation) Expired(p *Packet) bool {
+	return s.TTL > 0 && p.TTL <= 0
+}
+
+// expire is what a packet left circling until its TTL ran out costs: points
+// rather than a life, and the streak.
+func (s *Simulation) expire(p *Packet) {
+	s.Score = max(0, s.Score-ExpiredPenalty)
+	s.Streak = 0
+	s.expired = append(s.expired, expiry{At: Position{X: p.X, Y: p.Y}, Tick: s.GameTime})
+}
+
+// expiredAt reports whether a packet expired at x,y in the last few ticks.
+func (s *Simulation) expiredAt(x, y int) bool {
+	for _, e := range s.expired {
+		if e.At == (Position{X: x, Y: y}) && s.GameTime-e.Tick < ExpiryFlashTicks {
+			return true
+		}
+	}
+	return false
+}
+
+// forgetExpiries drops the expiries that are no longer shown.
+func (s *Simulation) forgetExpiries() {
+	kept := s.expired[:0]
+	for _, e := range s.expired {
+		if s.GameTime-e.Tick < ExpiryFlashTicks {
+			kept = append(kept, e)
+		}
+	}
+	s.expired = kept
+}
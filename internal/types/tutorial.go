@@ -0,0 +1,151 @@
+package types
+
+type TriggerKind int
+
+const (
+	// TriggerStart starts a step as soon as the one before it has; the
+	// first step of a tutorial starts with the level.
+	TriggerStart TriggerKind = iota
+	// TriggerContinue waits for the player to press SPACE or ENTER.
+	TriggerContinue
+	// TriggerPacketAt waits for any packet to reach Cell.
+	TriggerPacketAt
+	// TriggerSwitch waits for the junction at Cell to be switched.
+	TriggerSwitch
+	// TriggerDelivered waits for a Letter packet to be delivered.
+	TriggerDelivered
+)
+
+// Trigger is what has to happen for a tutorial step to start.
+type Trigger struct {
+	Kind   TriggerKind
+	Cell   Position
+	Letter rune
+}
+
+// TutorialStep is one message of a tutorial. It is shown from when its
+// trigger fires until the next step's does, with Point, if set, highlighted
+// on the board. A step that waits freezes the game until then.
+type TutorialStep struct {
+	On      Trigger
+	Message string
+	Point   *Position
+	Wait    bool
+}
+
+type tutorial struct {
+	steps []TutorialStep
+
+	// current is the step being shown, -1 before the first; delivered is
+	// how much of GoalProgress the triggers have already seen.
+	current   int
+	delivered int
+}
+
+// StartTutorial runs steps over the level. Only the junction switches the
+// script asks for are let through, so the player can't wander off it.
+func (m *GameModel) StartTutorial(steps []TutorialStep) {
+	m.tutorial = &tutorial{steps: steps, current: -1}
+	m.advanceTutorial(func(Trigger) bool { return false })
+}
+
+func (m *GameModel) tutorialStep() *TutorialStep {
+	if m.tutorial == nil || m.tutorial.current < 0 {
+		return nil
+	}
+	return &m.tutorial.steps[m.tutorial.current]
+}
+
+func (m *GameModel) nextTutorialStep() *TutorialStep {
+	if m.tutorial == nil || m.tutorial.current+1 >= len(m.tutorial.steps) {
+		return nil
+	}
+	return &m.tutorial.steps[m.tutorial.current+1]
+}
+
+// advanceTutorial moves on a step if its trigger has happened, and on
+// through any steps that start straight after it. One press never counts
+// for two steps.
+func (m *GameModel) advanceTutorial(happened func(Trigger) bool) {
+	if next := m.nextTutorialStep(); next != nil && (next.On.Kind == TriggerStart || happened(next.On)) {
+		m.tutorial.current++
+	}
+	for next := m.nextTutorialStep(); next != nil && next.On.Kind == TriggerStart; next = m.nextTutorialStep() {
+		m.tutorial.current++
+	}
+}
+
+// tutorialWaiting reports whether the game is frozen on a step until the
+// player does what the next one is waiting for.
+func (m *GameModel) tutorialWaiting() bool {
+	step := m.tutorialStep()
+	return step != nil && step.Wait && m.nextTutorialStep() != nil
+}
+
+// tutorialAllows reports whether j may be switched now.
+func (m *GameModel) tutorialAllows(j *Junction) bool {
+	if m.tutorial == nil {
+		return true
+	}
+	next := m.nextTutorialStep()
+	return next != nil && next.On.Kind == TriggerSwitch && next.On.Cell == Position{X: j.X, Y: j.Y}
+}
+
+// tutorialContinue moves on a step that is waiting for a key press and
+// reports whether there was one.
+func (m *GameModel) tutorialContinue() bool {
+	next := m.nextTutorialStep()
+	if !m.tutorialWaiting() || next.On.Kind != TriggerContinue {
+		return false
+	}
+	m.advanceTutorial(func(t Trigger) bool { return t.Kind == TriggerContinue })
+	return true
+}
+
+func (m *GameModel) tutorialSwitched(j *Junction) {
+	if m.tutorial == nil {
+		return
+	}
+	cell := Position{X: j.X, Y: j.Y}
+	m.advanceTutorial(func(t Trigger) bool { return t.Kind == TriggerSwitch && t.Cell == cell })
+}
+
+// tutorialTicked checks the triggers that watch the board after a tick.
+func (m *GameModel) tutorialTicked() {
+	if m.tutorial == nil {
+		return
+	}
+	delivered := m.GoalProgress[min(m.tutorial.delivered, len(m.GoalProgress)):]
+	m.tutorial.delivered = len(m.GoalProgress)
+
+	m.advanceTutorial(func(t Trigger) bool {
+		switch t.Kind {
+		case TriggerPacketAt:
+			return m.packetAt(t.Cell.X, t.Cell.Y)
+		case TriggerDelivered:
+			for _, letter := range delivered {
+				if letter == t.Letter {
+					return true
+				}
+			}
+		}
+		return false
+	})
+}
+
+func (m *GameModel) tutorialPoint(x, y int) bool {
+	step := m.tutorialStep()
+	return step != nil && step.Point != nil && *step.Point == Position{X: x, Y: y}
+}
+
+func (m *GameModel) renderTutorial() string {
+	step := m.tutorialStep()
+	if step == nil {
+		return ""
+	}
+	text := " 🎓 " + step.Message + " "
+	if next := m.nextTutorialStep(); m.tutorialWaiting() && next.On.Kind == TriggerContinue {
+		text += "[SPACE] Continue "
+	}
+	return BgMagenta + ColorWhite + ColorBright + text + ColorReset + "\n"
+}
@@ -5,8 +5,15 @@ import (
 	"strings"
 )
 
+// gridTop is the screen row renderGame draws the first grid row on.
+const gridTop = 2
+
 func (m *GameModel) View() string {
-	
+	if m.tooSmall() {
+		return m.renderTooSmall()
+	}
+	m.updateViewport()
+
 	if m.LevelComplete {
 		return m.renderLevelComplete()
 	}
@@ -15,7 +22,7 @@ func (m *GameModel) View() string {
 		return m.renderGameOver()
 	}
 
-	return m.renderGame()
+	return m.frame(m.renderGame())
 }
 
 
@@ -39,15 +46,63 @@ func (m *GameModel) renderGame() string {
 	}
 
 	
+	for _, e := range m.expired {
+		if m.expiredAt(e.At.X, e.At.Y) && !m.packetAt(e.At.X, e.At.Y) {
+			display[e.At.Y][e.At.X] = '×'
+		}
+	}
+
 	for _, packet := range m.Packets {
 		if m.IsValidPosition(packet.X, packet.Y) {
 			display[packet.Y][packet.X] = packet.GetChar()
 		}
 	}
 
+	hints := m.hintCells()
+	for cell, char := range hints {
+		if cell.Y < len(display) && cell.X < len(display[cell.Y]) {
+			display[cell.Y][cell.X] = char
+		}
+	}
+
 	
-	for y, row := range display {
-		for x, char := range row {
+	routePath, routeWrong := m.routeHintCells()
+	preview := m.previewCells()
+
+	v := m.view
+	for y := v.y; y < v.y+v.rows && y < len(display); y++ {
+		row := display[y]
+		width := 0
+		for x := v.x; x < len(row); x++ {
+			char := row[x]
+			width += runeWidth(char)
+			if width > v.cols {
+				break
+			}
+			if _, isHint := hints[Position{X: x, Y: y}]; isHint {
+				builder.WriteString(BgRed + ColorWhite + ColorBright + string(char) + ColorReset)
+				continue
+			}
+			if char == '×' && m.expiredAt(x, y) {
+				builder.WriteString(BgYellow + ColorRed + ColorBright + string(char) + ColorReset)
+				continue
+			}
+			if m.tutorialPoint(x, y) {
+				builder.WriteString(BgMagenta + ColorWhite + ColorBright + string(char) + ColorReset)
+				continue
+			}
+			if cell := (Position{X: x, Y: y}); routeWrong[cell] {
+				builder.WriteString(BgRed + ColorWhite + ColorBright + string(char) + ColorReset)
+				continue
+			} else if routePath[cell] && !m.packetAt(x, y) {
+				builder.WriteString(BgGreen + ColorBlack + string(char) + ColorReset)
+				continue
+			} else if delivered, traced := preview[cell]; traced {
+				if colored, ok := previewChar(char, delivered); ok {
+					builder.WriteString(colored)
+					continue
+				}
+			}
 			coloredChar := getColoredChar(char, x, y, m)
 			builder.WriteString(coloredChar)
 		}
@@ -56,29 +111,48 @@ func (m *GameModel) renderGame() string {
 
 	
 	builder.WriteString("╔══════════════════════════════════════════════════════════════════════════════╗\n")
-	builder.WriteString(fmt.Sprintf("║ "+ColorYellow+"Level: %d"+ColorReset+" │ "+
+	stage := fmt.Sprintf("Level: %d", m.Level)
+	switch m.Mode {
+	case ModeSurvival:
+		stage = fmt.Sprintf("Wave: %d │ Streak: x%d", m.Wave(), m.Multiplier())
+	case ModeDaily:
+		stage = "Daily Challenge"
+	case ModePuzzle:
+		stage = fmt.Sprintf("Puzzle: %d │ Switches: %d (par %d)", m.Level, m.Switches, m.Par)
+	case ModeTutorial:
+		stage = "Tutorial"
+	}
+	lives := fmt.Sprintf("Lives: %d", m.Lives)
+	if m.Mode == ModeTimeAttack {
+		lives = "Clock: " + formatClock(m.TimeLeft)
+	}
+	builder.WriteString(fmt.Sprintf("║ "+ColorYellow+"%s"+ColorReset+" │ "+
 		ColorYellow+"Score: %d"+ColorReset+" │ "+
-		ColorRed+"Lives: %d"+ColorReset+" │ "+
+		ColorRed+"%s"+ColorReset+" │ "+
 		ColorGreen+"Packets: %d"+ColorReset+" │ "+
 		ColorCyan+"Time: %ds"+ColorReset+" ║\n",
-		m.Level, m.Score, m.Lives, len(m.Packets), m.GameTime/5))
+		stage, m.Score, lives, len(m.Packets), int(m.Elapsed.Seconds())))
 	builder.WriteString("╚══════════════════════════════════════════════════════════════════════════════╝\n")
 
 	
 	builder.WriteString(ColorMagenta + "🎯 Goal: " + ColorReset + m.CurrentGoal + "\n")
-	builder.WriteString(ColorCyan + "📝 Progress: " + ColorReset)
-	for _, letter := range m.GoalProgress {
-		builder.WriteString(ColorGreen + ColorBright + string(letter) + ColorReset + " ")
-	}
-	needed := len(m.TargetWord) - len(m.GoalProgress)
-	if needed > 0 {
-		builder.WriteString(ColorWhite + fmt.Sprintf("(need %d more: %s)", needed, m.TargetWord) + ColorReset)
-	}
-	builder.WriteString("\n")
+	builder.WriteString(ColorCyan + "📝 Progress: " + ColorReset + m.renderProgress() + "\n")
 
 	
 	builder.WriteString(ColorMagenta + "Junctions: " + ColorReset)
-	for _, junction := range m.Junctions {
+	legendWidth := len("Junctions: ")
+	shown, switchable := 0, 0
+	for _, junction := range m.SwitchableJunctions() {
+		switchable++
+		if junction.ID == 0 {
+			continue
+		}
+		// Leave room for the "+N more" tail rather than wrapping the line.
+		if legendWidth+4+len("+99 more") > m.screenWidth()-m.view.left {
+			continue
+		}
+		legendWidth += 4
+		shown++
 		dir := junction.GetActiveDirection()
 		symbol := "?"
 		color := ColorWhite
@@ -98,31 +172,70 @@ func (m *GameModel) renderGame() string {
 		}
 		builder.WriteString(fmt.Sprintf(ColorBright+"%c"+ColorReset+":"+color+"%s"+ColorReset+" ", junction.ID, symbol))
 	}
+	if switchable > shown {
+		builder.WriteString(fmt.Sprintf(ColorWhite+"+%d more"+ColorReset, switchable-shown))
+	}
 	builder.WriteString("\n")
 
 	
-	if len(m.Packets) > 0 {
+	if len(m.Packets) > 0 || m.Mode == ModePuzzle {
 		builder.WriteString(ColorYellow + "Active Packets: " + ColorReset)
 		for i, packet := range m.Packets {
 			if i < 5 { 
-				color := packet.GetColor()
-				builder.WriteString(fmt.Sprintf(color+"%c"+ColorReset+" ", packet.PacketType))
+				color := m.packetColor(packet)
+				builder.WriteString(fmt.Sprintf(color+"%c"+ColorReset, packet.PacketType))
+				if m.TTL > 0 {
+					builder.WriteString(fmt.Sprintf(ColorWhite+"(%d)"+ColorReset, packet.TTL))
+				}
+				builder.WriteString(" ")
 			}
 		}
 		if len(m.Packets) > 5 {
 			builder.WriteString(fmt.Sprintf("+"+ColorWhite+"%d more..."+ColorReset, len(m.Packets)-5))
 		}
+		if m.Mode == ModePuzzle {
+			builder.WriteString(m.renderQueue())
+		}
 		builder.WriteString("\n")
 	}
 
-	if m.Paused {
+	if m.Paused && m.PuzzleStarted() {
+		builder.WriteString(BgYellow + ColorBlack + " 🧩 STOPPED - junctions are locked, SPACE to run or . to step " + ColorReset + "\n")
+	} else if m.Paused && m.Mode == ModePuzzle {
+		builder.WriteString(BgYellow + ColorBlack + " 🧩 STOPPED - set the junctions, then SPACE to run or . to step " + ColorReset + "\n")
+	} else if m.Paused {
 		builder.WriteString(BgYellow + ColorBlack + " ⏸️  PAUSED - Press SPACE to continue " + ColorReset + "\n")
 	}
+	if m.Autopilot {
+		builder.WriteString(BgBlue + ColorWhite + ColorBright + " 🤖 AUTOPILOT - press A to take over (no scores recorded) " + ColorReset + "\n")
+	}
 
 		
-	builder.WriteString(ColorWhite + "Controls: " + ColorGreen + "[1-9]" + ColorWhite + " Switch Junctions │ " +
-		ColorYellow + "[SPACE]" + ColorWhite + " Pause │ " +
-		ColorRed + "[Q]" + ColorWhite + " Quit" + ColorReset + "\n")
+	if m.Mode == ModePuzzle {
+		builder.WriteString(ColorWhite + "Keys: " + ColorGreen + "[1-9/Click]" + ColorWhite + " Switch │ " +
+			ColorYellow + "[SPACE]" + ColorWhite + " Run │ " +
+			ColorYellow + "[.]" + ColorWhite + " Step │ " +
+			ColorCyan + "[TAB]" + ColorWhite + " Scores │ " +
+			ColorRed + "[Q]" + ColorWhite + " Quit" + ColorReset + "\n")
+	} else {
+		builder.WriteString(ColorWhite + "Keys: " + ColorGreen + "[1-9/Click]" + ColorWhite + " Switch │ " +
+			ColorYellow + "[SPACE]" + ColorWhite + " Pause │ " +
+			ColorCyan + "[TAB]" + ColorWhite + " Scores │ " +
+			ColorGreen + "[?]" + ColorWhite + " Hint │ " +
+			ColorRed + "[Q]" + ColorWhite + " Quit" + ColorReset + "\n")
+	}
+	builder.WriteString(ColorWhite + "      " + ColorGreen + "[←↑↓→/HJKL]" + ColorWhite + " Select │ " +
+		ColorGreen + "[ENTER]" + ColorWhite + " Switch │ " +
+		ColorGreen + "[F]" + ColorWhite + " Jump │ " +
+		ColorBlue + "[A]" + ColorWhite + " Auto │ " +
+		ColorGreen + "[V]" + ColorWhite + " Paths" + ColorReset + "\n")
+
+	builder.WriteString(m.renderRouteHint())
+	builder.WriteString(m.renderTutorial())
+
+	if m.hints != nil {
+		builder.WriteString(BgRed + ColorWhite + ColorBright + " JUMP: type a junction label (ESC to cancel) " + ColorReset + "\n")
+	}
 
 	return builder.String()
 }
@@ -132,7 +245,7 @@ func (m *GameModel) renderLevelComplete() string {
 	var builder strings.Builder
 
 	
-	gameView := m.renderGame()
+	gameView := m.frame(m.renderGame())
 
 	
 	lines := strings.Split(gameView, "\n")
@@ -141,16 +254,30 @@ func (m *GameModel) renderLevelComplete() string {
 	for i, line := range lines {
 		switch i {
 case height/2 - 2:
-			if m.Level < MaxLevel {
+			if m.Mode == ModePuzzle {
+				msg := fmt.Sprintf("🧩 PUZZLE SOLVED in %d switches (par %d) %s", m.Switches, m.Par, stars(PuzzleStars(m.Switches, m.Par)))
+				width := m.screenWidth()
+				padding := (width - len(msg)) / 2
+				if padding > 0 {
+					line = strings.Repeat(" ", padding) + BgGreen + ColorWhite + ColorBright + msg + ColorReset + strings.Repeat(" ", padding)
+				}
+			} else if m.Mode == ModeTutorial {
+				msg := "🎓 TUTORIAL COMPLETE! 🎓"
+				width := m.screenWidth()
+				padding := (width - len(msg)) / 2
+				if padding > 0 {
+					line = strings.Repeat(" ", padding) + BgGreen + ColorWhite + ColorBright + msg + ColorReset + strings.Repeat(" ", padding)
+				}
+			} else if m.Level != MaxLevel {
 				msg := "🎉 LEVEL COMPLETE! 🎉"
-				width := 80
+				width := m.screenWidth()
 				padding := (width - len(msg)) / 2
 				if padding > 0 {
 					line = strings.Repeat(" ", padding) + BgGreen + ColorWhite + ColorBright + msg + ColorReset + strings.Repeat(" ", padding)
 				}
 			} else {
 				msg := "🏆 ALL LEVELS COMPLETE! YOU'RE A NETWORK MASTER! 🏆"
-				width := 80
+				width := m.screenWidth()
 				padding := (width - len(msg)) / 2
 				if padding > 0 {
 					line = strings.Repeat(" ", padding) + BgGold + ColorBlack + ColorBright + msg + ColorReset + strings.Repeat(" ", padding)
@@ -158,10 +285,14 @@ case height/2 - 2:
 			}
 		case height / 2:
 			msg := "Press R for next level"
-			if m.Level >= MaxLevel {
-				msg = "Press R to restart"
+			if m.Mode == ModePuzzle {
+				msg = "Press R for the next puzzle"
+			} else if m.Mode == ModeTutorial {
+				msg = "Press R to start the campaign"
+			} else if m.Level == MaxLevel {
+				msg = "Press R to keep going with generated networks"
 			}
-			width := 80
+			width := m.screenWidth()
 			padding := (width - len(msg)) / 2
 			if padding > 0 {
 				line = strings.Repeat(" ", padding) + ColorYellow + ColorBright + msg + ColorReset + strings.Repeat(" ", padding)
@@ -177,14 +308,19 @@ case height/2 - 2:
 func (m *GameModel) renderGameOver() string {
 	var builder strings.Builder
 
-	width := 80
-	height := 24
+	width := m.screenWidth()
+	height := m.screenHeight()
 
 	for i := 0; i < height/2-3; i++ {
 		builder.WriteString("\n")
 	}
 
 	title := "KERNEL PANIC!"
+	if m.TimedOut() {
+		title = "TIME'S UP!"
+	} else if m.Mode == ModePuzzle {
+		title = "PUZZLE FAILED"
+	}
 	padding := (width - len(title)) / 2
 	builder.WriteString(strings.Repeat(" ", padding))
 	builder.WriteString(ColorRed + ColorBright + title + ColorReset + "\n\n")
@@ -195,11 +331,20 @@ func (m *GameModel) renderGameOver() string {
 	builder.WriteString(ColorYellow + scoreText + ColorReset + "\n\n")
 
 	levelText := fmt.Sprintf("Level Reached: %d", m.Level)
+	switch m.Mode {
+	case ModeSurvival:
+		levelText = fmt.Sprintf("Words Spelled: %d", m.WordsCompleted)
+	case ModePuzzle:
+		levelText = fmt.Sprintf("Puzzle: %d", m.Level)
+	}
 	padding = (width - len(levelText)) / 2
 	builder.WriteString(strings.Repeat(" ", padding))
 	builder.WriteString(ColorCyan + levelText + ColorReset + "\n\n")
 
 	controlsText := "Press [R] to restart or [Q] to quit"
+	if m.Mode == ModePuzzle {
+		controlsText = "Press [R] to try again or [Q] to quit"
+	}
 	padding = (width - len(controlsText)) / 2
 	builder.WriteString(strings.Repeat(" ", padding))
 	builder.WriteString(ColorWhite + controlsText + ColorReset + "\n")
@@ -210,7 +355,7 @@ func (m *GameModel) renderGameOver() string {
 func getColoredChar(char rune, x, y int, m *GameModel) string {
 	for _, packet := range m.Packets {
 		if packet.X == x && packet.Y == y {
-			color := packet.GetColor()
+			color := m.packetColor(packet)
 			return color + ColorBright + string(char) + ColorReset
 		}
 	}
@@ -218,6 +363,12 @@ func getColoredChar(char rune, x, y int, m *GameModel) string {
 	
 	for _, junction := range m.Junctions {
 		if junction.X == x && junction.Y == y {
+			if junction == m.cursor {
+				return BgYellow + ColorBlack + ColorBright + string(char) + ColorReset
+			}
+			if junction == m.hovered {
+				return BgCyan + ColorBlack + ColorBright + string(char) + ColorReset
+			}
 			return ColorMagenta + ColorBright + string(char) + ColorReset
 		}
 	}
@@ -234,6 +385,8 @@ func getColoredChar(char rune, x, y int, m *GameModel) string {
 		return ColorWhite + string(char) + ColorReset
 	case '+': 
 		return ColorMagenta + ColorBright + string(char) + ColorReset
+	case Sink:
+		return BgRed + ColorWhite + ColorBright + string(char) + ColorReset
 	default:
 		
 		if IsLetterDestination(char) {
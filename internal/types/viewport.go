@@ -0,0 +1,153 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// hudLines is the most lines renderGame draws below the grid.
+	hudLines = 13
+
+	// boardWidth is the width of the status box and control lines.
+	boardWidth = 80
+
+	minGridRows = 6
+	minWidth    = boardWidth
+	minHeight   = gridTop + minGridRows + hudLines
+)
+
+// viewport is the part of the grid on screen and where it is drawn.
+type viewport struct {
+	left, top  int // screen padding used to center the board
+	x, y       int // first grid column and row shown
+	cols, rows int // grid columns and rows shown
+}
+
+// SetSize tells the model how big the terminal is. A zero size means unknown,
+// in which case the whole board is drawn unpadded.
+func (m *GameModel) SetSize(width, height int) {
+	m.width, m.height = width, height
+}
+
+func (m *GameModel) tooSmall() bool {
+	return m.width > 0 && (m.width < minWidth || m.height < minHeight)
+}
+
+func (m *GameModel) gridWidth() int {
+	widest := 0
+	for _, row := range m.Grid {
+		width := 0
+		for _, char := range row {
+			width += runeWidth(char)
+		}
+		widest = max(widest, width)
+	}
+	return widest
+}
+
+// updateViewport fits the grid to the terminal, scrolling towards wherever
+// the most packets are when it doesn't fit.
+func (m *GameModel) updateViewport() {
+	gridWidth, gridHeight := m.gridWidth(), len(m.Grid)
+	if m.width == 0 || m.height == 0 {
+		m.view = viewport{cols: gridWidth, rows: gridHeight}
+		return
+	}
+
+	v := viewport{
+		cols: min(gridWidth, m.width),
+		rows: min(gridHeight, m.height-gridTop-hudLines),
+	}
+	v.left = max(0, (m.width-max(v.cols, boardWidth))/2)
+	v.top = max(0, (m.height-(gridTop+v.rows+hudLines))/2)
+
+	xs := make([]int, len(m.Packets))
+	ys := make([]int, len(m.Packets))
+	for i, packet := range m.Packets {
+		xs[i], ys[i] = packet.X, packet.Y
+	}
+	v.x = follow(xs, m.view.x, v.cols, gridWidth)
+	v.y = follow(ys, m.view.y, v.rows, gridHeight)
+
+	m.view = v
+}
+
+// follow picks where a window of size cells starts along one axis so it
+// covers the most coords, preferring to stay near current so the view
+// doesn't jitter between equally busy spots.
+func follow(coords []int, current, size, total int) int {
+	if size >= total {
+		return 0
+	}
+
+	best, bestCount := 0, -1
+	for start := 0; start <= total-size; start++ {
+		count := 0
+		for _, c := range coords {
+			if c >= start && c < start+size {
+				count++
+			}
+		}
+		if count > bestCount || (count == bestCount && abs(start-current) < abs(best-current)) {
+			best, bestCount = start, count
+		}
+	}
+	return best
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// frame pads a rendered screen so it sits in the viewport's place on the
+// terminal.
+func (m *GameModel) frame(screen string) string {
+	if m.view.left == 0 && m.view.top == 0 {
+		return screen
+	}
+
+	var builder strings.Builder
+	builder.WriteString(strings.Repeat("\n", m.view.top))
+	padding := strings.Repeat(" ", m.view.left)
+	for _, line := range strings.SplitAfter(screen, "\n") {
+		if line != "" && line != "\n" {
+			builder.WriteString(padding)
+		}
+		builder.WriteString(line)
+	}
+	return builder.String()
+}
+
+func (m *GameModel) screenWidth() int {
+	if m.width > 0 {
+		return m.width
+	}
+	return boardWidth
+}
+
+func (m *GameModel) screenHeight() int {
+	if m.height > 0 {
+		return m.height
+	}
+	return 24
+}
+
+func (m *GameModel) renderTooSmall() string {
+	lines := []string{
+		"Terminal too small",
+		fmt.Sprintf("Need at least %dx%d, have %dx%d", minWidth, minHeight, m.width, m.height),
+		"Resize the window or press Q to quit",
+	}
+
+	var builder strings.Builder
+	builder.WriteString(strings.Repeat("\n", max(0, (m.height-len(lines))/2)))
+	for _, line := range lines {
+		padding := max(0, (m.width-len(line))/2)
+		builder.WriteString(strings.Repeat(" ", padding) + ColorYellow + line + ColorReset + "\n")
+	}
+	return builder.String()
+}
@@ -1,25 +1,108 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"log"
 	"os"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/maverickkamal/Packet-Rush/internal/daily"
 	"github.com/maverickkamal/Packet-Rush/internal/game"
+	"github.com/maverickkamal/Packet-Rush/internal/levels"
+	"github.com/maverickkamal/Packet-Rush/internal/replay"
+	"github.com/maverickkamal/Packet-Rush/internal/savegame"
+	"github.com/maverickkamal/Packet-Rush/internal/scores"
 )
 
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "validate":
+			os.Exit(runValidate(os.Args[2:]))
+		case "replay":
+			os.Exit(runReplay(os.Args[2:]))
+		case "edit":
+			os.Exit(runEdit(os.Args[2:]))
+		case "generate":
+			os.Exit(runGenerate(os.Args[2:]))
+		case "solve":
+			os.Exit(runSolve(os.Args[2:]))
+		}
+	}
+
+	levelDir := flag.String("levels", "", "directory of JSON level files that override the built-in levels")
+	recordPath := flag.String("record", "", "write a replay of the session to this file")
+	ordered := flag.Bool("ordered", false, "only count letters delivered in the order the word is spelled")
+	flag.Parse()
+
+	if *ordered {
+		levels.SpellInOrder()
+	}
+
+	if *levelDir != "" {
+		if err := levels.LoadLevelDir(*levelDir); err != nil {
+			log.Printf("Error loading levels: %v", err)
+			os.Exit(1)
+		}
+	}
+
 	fmt.Println("🚀 Starting Packet Rush...")
 
 	coordinator := game.NewGameCoordinator()
+	if *recordPath != "" {
+		coordinator.Recorder = replay.NewRecorder()
+	}
+	if path, err := scores.DefaultPath(); err == nil {
+		table, err := scores.Load(path)
+		if err != nil {
+			log.Printf("High scores disabled, could not read %s: %v", path, err)
+		} else {
+			coordinator.Scores = table
+		}
+	}
 
-	p := tea.NewProgram(coordinator)
+	if path, err := daily.DefaultPath(); err == nil {
+		history, err := daily.Load(path)
+		if err != nil {
+			log.Printf("Daily history disabled, could not read %s: %v", path, err)
+		} else {
+			coordinator.Daily = history
+		}
+	}
+
+	var save *savegame.SaveGame
+	if path, err := savegame.DefaultPath(); err == nil {
+		coordinator.SavePath = path
+		save, err = savegame.Load(path)
+		if err != nil {
+			log.Printf("Ignoring saved game: %v", err)
+		}
+	}
+	coordinator.ShowMenu(save)
+
+	p := tea.NewProgram(coordinator, tea.WithMouseCellMotion())
 
 	if _, err := p.Run(); err != nil {
 		log.Printf("Error running game: %v", err)
 		os.Exit(1)
 	}
 
+	if err := coordinator.SaveProgress(); err != nil {
+		log.Printf("Error saving game: %v", err)
+	}
+
+	if coordinator.Recorder != nil {
+		if err := coordinator.Recorder.Replay.Save(*recordPath); err != nil {
+			log.Printf("Error saving replay: %v", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Replay saved to %s\n", *recordPath)
+	}
+
+	if share := coordinator.DailyShare(); share != "" {
+		fmt.Println(share)
+	}
+
 	fmt.Println("Thanks for playing Packet Rush! - Maverick Kamal")
 }
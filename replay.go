@@ -0,0 +1,40 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/maverickkamal/Packet-Rush/internal/levels"
+	"github.com/maverickkamal/Packet-Rush/internal/replay"
+)
+
+func runReplay(args []string) int {
+	flags := flag.NewFlagSet("replay", flag.ExitOnError)
+	levelDir := flags.String("levels", "", "directory of JSON level files the replay was recorded with")
+	flags.Parse(args)
+
+	if flags.NArg() != 1 {
+		fmt.Println("Usage: packet-rush replay [-levels dir] <file>")
+		return 2
+	}
+
+	if *levelDir != "" {
+		if err := levels.LoadLevelDir(*levelDir); err != nil {
+			fmt.Printf("Error loading levels: %v\n", err)
+			return 1
+		}
+	}
+
+	r, err := replay.Load(flags.Arg(0))
+	if err != nil {
+		fmt.Printf("Error loading replay: %v\n", err)
+		return 1
+	}
+
+	if _, err := tea.NewProgram(replay.NewPlayer(r)).Run(); err != nil {
+		fmt.Printf("Error running replay: %v\n", err)
+		return 1
+	}
+	return 0
+}
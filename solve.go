@@ -0,0 +1,68 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+
+	"github.com/maverickkamal/Packet-Rush/internal/bot"
+	"github.com/maverickkamal/Packet-Rush/internal/levels"
+	"github.com/maverickkamal/Packet-Rush/internal/types"
+)
+
+func runSolve(args []string) int {
+	flags := flag.NewFlagSet("solve", flag.ExitOnError)
+	levelDir := flags.String("levels", "", "directory of JSON level files that override the built-in levels")
+	seedCount := flags.Int("seeds", 10, "games to play per level, with seeds 1..n")
+	maxTicks := flags.Int("ticks", bot.DefaultMaxTicks, "give up on a game after this many ticks")
+	flags.Usage = func() {
+		fmt.Println("Usage: packet-rush solve [flags] [level...]")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	if *levelDir != "" {
+		if err := levels.LoadLevelDir(*levelDir); err != nil {
+			fmt.Printf("Error loading levels: %v\n", err)
+			return 1
+		}
+	}
+
+	var levelNumbers []int
+	for _, arg := range flags.Args() {
+		level, err := strconv.Atoi(arg)
+		if err != nil || level < 1 {
+			fmt.Printf("Not a level number: %s\n", arg)
+			return 2
+		}
+		levelNumbers = append(levelNumbers, level)
+	}
+	if len(levelNumbers) == 0 {
+		for level := 1; level <= types.MaxLevel; level++ {
+			levelNumbers = append(levelNumbers, level)
+		}
+	}
+
+	seeds := make([]int64, *seedCount)
+	for i := range seeds {
+		seeds[i] = int64(i + 1)
+	}
+
+	failed := false
+	for _, level := range levelNumbers {
+		report := bot.CheckLevel(level, seeds, *maxTicks)
+		if !report.Beatable() {
+			fmt.Printf("Level %d: NOT BEATEN in %d games\n", level, len(seeds))
+			failed = true
+			continue
+		}
+		ticks, lives := report.AverageWin()
+		fmt.Printf("Level %d: beaten %d/%d (avg %.0f ticks, %.1f lives left)\n",
+			level, report.Wins(), len(seeds), ticks, lives)
+	}
+
+	if failed {
+		return 1
+	}
+	return 0
+}
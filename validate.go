@@ -0,0 +1,67 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/maverickkamal/Packet-Rush/internal/levels"
+	"github.com/maverickkamal/Packet-Rush/internal/types"
+)
+
+func runValidate(args []string) int {
+	flags := flag.NewFlagSet("validate", flag.ExitOnError)
+	levelDir := flags.String("levels", "", "directory of JSON level files to validate as well")
+	generated := flags.Int("generated", 0, "also validate this many generated levels past the built-in ones")
+	flags.Parse(args)
+
+	if *levelDir != "" {
+		if err := levels.LoadLevelDir(*levelDir); err != nil {
+			fmt.Printf("Error loading levels: %v\n", err)
+			return 1
+		}
+	}
+
+	failed := false
+	for level := 1; level <= types.MaxLevel; level++ {
+		name := fmt.Sprintf("Built-in level %d", level)
+		if reportLevel(name, levels.BuiltinLevelData(level)) {
+			failed = true
+		}
+	}
+	for level := types.MaxLevel + 1; level <= types.MaxLevel+*generated; level++ {
+		name := fmt.Sprintf("Generated level %d", level)
+		if reportLevel(name, levels.GeneratedLevel(level)) {
+			failed = true
+		}
+	}
+	for _, file := range levels.ExternalLevels() {
+		data, err := file.LevelData()
+		if err != nil {
+			fmt.Printf("%s: %v\n", file.Path, err)
+			failed = true
+			continue
+		}
+		if reportLevel(fmt.Sprintf("%s (level %d)", file.Path, file.Level), data) {
+			failed = true
+		}
+	}
+
+	if failed {
+		return 1
+	}
+	return 0
+}
+
+func reportLevel(name string, data levels.LevelData) bool {
+	issues := levels.Validate(data)
+	if len(issues) == 0 {
+		fmt.Printf("%s: OK\n", name)
+		return false
+	}
+
+	fmt.Printf("%s:\n", name)
+	for _, issue := range issues {
+		fmt.Printf("  %s\n", issue)
+	}
+	return levels.HasErrors(issues)
+}